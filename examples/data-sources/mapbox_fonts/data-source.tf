@@ -0,0 +1,3 @@
+data "mapbox_fonts" "example" {
+  username = "example"
+}
@@ -0,0 +1,9 @@
+data "mapbox_style_document" "basemap" {
+  username = "example"
+  style_id = "cjz5g2fue0ed61cp6wy7tn1xe"
+}
+
+resource "local_file" "basemap" {
+  filename = "${path.module}/styles/basemap.json"
+  content  = data.mapbox_style_document.basemap.json
+}
@@ -0,0 +1,8 @@
+data "mapbox_styles" "basemaps" {
+  username   = "example"
+  name_regex = "^Basemap"
+}
+
+output "basemap_style_ids" {
+  value = data.mapbox_styles.basemaps.styles[*].id
+}
@@ -0,0 +1,11 @@
+check "tileset_published" {
+  data "mapbox_tileset_job" "last_success" {
+    tileset_id = mapbox_tileset.stores.id
+    stage      = "success"
+  }
+
+  assert {
+    condition     = data.mapbox_tileset_job.last_success.job_id == mapbox_tileset.stores.job_id
+    error_message = "The last publish job of the tileset did not succeed."
+  }
+}
@@ -0,0 +1,8 @@
+data "mapbox_tileset_jobs" "failed" {
+  tileset_id = "example.stores"
+  stage      = "failed"
+}
+
+output "publish_errors" {
+  value = flatten(data.mapbox_tileset_jobs.failed.jobs[*].errors)
+}
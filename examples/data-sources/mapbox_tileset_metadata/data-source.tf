@@ -0,0 +1,7 @@
+data "mapbox_tileset_metadata" "streets" {
+  tileset_ids = ["mapbox.mapbox-streets-v8", "mapbox.mapbox-terrain-v2"]
+}
+
+output "road_fields" {
+  value = one([for layer in data.mapbox_tileset_metadata.streets.vector_layers : layer.fields if layer.id == "road"])
+}
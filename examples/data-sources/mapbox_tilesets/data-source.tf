@@ -0,0 +1,10 @@
+data "mapbox_tilesets" "private" {
+  username   = "example"
+  type       = "vector"
+  visibility = "private"
+  sort_by    = "modified"
+}
+
+output "stale_tilesets" {
+  value = [for tileset in data.mapbox_tilesets.private.tilesets : tileset.id if timecmp(tileset.modified, "2024-01-01T00:00:00Z") < 0]
+}
@@ -0,0 +1,5 @@
+# Datasets of the account of the access token can be imported using the dataset ID
+terraform import mapbox_dataset.stores cjz5g2fue0ed61cp6wy7tn1xe
+
+# Datasets of other accounts are imported using the username and the dataset ID
+terraform import mapbox_dataset.stores example/cjz5g2fue0ed61cp6wy7tn1xe
@@ -0,0 +1,5 @@
+resource "mapbox_dataset" "stores" {
+  username    = "example"
+  name        = "Stores"
+  description = "Store locations maintained by Terraform"
+}
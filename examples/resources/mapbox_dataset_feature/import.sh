@@ -0,0 +1,2 @@
+# Dataset features can be imported using the username, the dataset ID and the feature ID
+terraform import mapbox_dataset_feature.berlin example/cjz5g2fue0ed61cp6wy7tn1xe/berlin
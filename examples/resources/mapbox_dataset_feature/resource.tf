@@ -0,0 +1,20 @@
+resource "mapbox_dataset" "stores" {
+  username = "example"
+  name     = "Stores"
+}
+
+resource "mapbox_dataset_feature" "berlin" {
+  username   = mapbox_dataset.stores.username
+  dataset_id = mapbox_dataset.stores.id
+  feature_id = "berlin"
+
+  geometry = jsonencode({
+    type        = "Point"
+    coordinates = [13.405, 52.52]
+  })
+
+  properties = jsonencode({
+    name    = "Berlin"
+    opening = "09:00"
+  })
+}
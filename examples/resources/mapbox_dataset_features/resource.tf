@@ -0,0 +1,10 @@
+resource "mapbox_dataset" "stores" {
+  username = "example"
+  name     = "Stores"
+}
+
+resource "mapbox_dataset_features" "stores" {
+  username   = mapbox_dataset.stores.username
+  dataset_id = mapbox_dataset.stores.id
+  path       = "${path.module}/data/stores.geojson"
+}
@@ -0,0 +1,18 @@
+resource "mapbox_font" "inter_bold" {
+  username = "example"
+  path     = "${path.module}/fonts/Inter-Bold.ttf"
+}
+
+resource "mapbox_style_layer" "labels" {
+  username     = "example"
+  style_id     = "cjz5g2fue0ed61cp6wy7tn1xe"
+  layer_id     = "labels"
+  type         = "symbol"
+  source       = "composite"
+  source_layer = "place_label"
+
+  layout = {
+    "text-field" = ["get", "name"]
+    "text-font"  = mapbox_font.inter_bold.fonts
+  }
+}
@@ -0,0 +1,2 @@
+# Styles can be imported using the username and the style ID
+terraform import mapbox_style.example example/cjz5g2fue0ed61cp6wy7tn1xe
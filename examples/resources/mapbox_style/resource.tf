@@ -0,0 +1,15 @@
+resource "mapbox_style" "example" {
+  username = "example"
+  style = jsonencode({
+    version = 8
+    name    = "example"
+    sources = {}
+    layers = [
+      {
+        id    = "background"
+        type  = "background"
+        paint = { "background-color" = "#f8f4f0" }
+      }
+    ]
+  })
+}
@@ -0,0 +1,2 @@
+# Style icons can be imported using the username, the style ID and the icon name
+terraform import mapbox_style_icon.marker example/cjz5g2fue0ed61cp6wy7tn1xe/marker
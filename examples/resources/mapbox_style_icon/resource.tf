@@ -0,0 +1,6 @@
+resource "mapbox_style_icon" "marker" {
+  username  = "example"
+  style_id  = "cjz5g2fue0ed61cp6wy7tn1xe"
+  icon_name = "marker"
+  svg_path  = "${path.module}/icons/marker.svg"
+}
@@ -0,0 +1,2 @@
+# Style layers can be imported using the username, the style ID and the layer ID
+terraform import mapbox_style_layer.roads example/cjz5g2fue0ed61cp6wy7tn1xe/roads
@@ -0,0 +1,19 @@
+resource "mapbox_style_layer" "roads" {
+  username     = "example"
+  style_id     = "cjz5g2fue0ed61cp6wy7tn1xe"
+  layer_id     = "roads"
+  type         = "line"
+  source       = "composite"
+  source_layer = "road"
+  before       = "labels"
+  filter       = ["==", ["get", "class"], "street"]
+
+  paint = {
+    "line-color" = "#404040"
+    "line-width" = ["interpolate", ["linear"], ["zoom"], 5, 0.5, 18, 6]
+  }
+
+  layout = {
+    "line-cap" = "round"
+  }
+}
@@ -0,0 +1,5 @@
+resource "mapbox_style_sprite" "icons" {
+  username = "example"
+  style_id = "cjz5g2fue0ed61cp6wy7tn1xe"
+  glob     = "${path.module}/icons/*.svg"
+}
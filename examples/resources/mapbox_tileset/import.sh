@@ -0,0 +1,2 @@
+# Tilesets can be imported using the tileset ID
+terraform import mapbox_tileset.stores example.stores
@@ -0,0 +1,53 @@
+resource "mapbox_tileset" "stores" {
+  tileset_id = "example.stores"
+  name       = "Stores"
+
+  recipe = jsonencode({
+    version = 1
+    layers = {
+      stores = {
+        source  = mapbox_tileset_source.stores.id
+        minzoom = 0
+        maxzoom = 12
+      }
+    }
+  })
+
+  # Publish again whenever the source data changes.
+  publish_triggers = mapbox_tileset_source.stores.file_hashes
+
+  # Apply edits to the stores layer without reprocessing the whole source.
+  update_mode = "incremental"
+  changesets = {
+    stores = "${path.module}/stores-changes.geojsonld"
+  }
+}
+
+# The recipe can also be written as layer blocks, which are compiled into the recipe.
+resource "mapbox_tileset" "roads" {
+  tileset_id = "example.roads"
+  name       = "Roads"
+
+  layer {
+    name    = "roads"
+    source  = mapbox_tileset_source.roads.id
+    minzoom = 4
+    maxzoom = 14
+
+    features {
+      filter = jsonencode(["!=", ["get", "class"], "path"])
+
+      attributes {
+        allowed_output = ["class", "name"]
+      }
+    }
+
+    tiles {
+      layer_size = 2500
+
+      union {
+        group_by = ["class"]
+      }
+    }
+  }
+}
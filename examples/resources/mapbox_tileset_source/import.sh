@@ -0,0 +1,2 @@
+# Tileset sources can be imported using the username and the source ID
+terraform import mapbox_tileset_source.stores example/stores
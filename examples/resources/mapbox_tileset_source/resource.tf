@@ -0,0 +1,12 @@
+resource "mapbox_tileset_source" "stores" {
+  username  = "example"
+  source_id = "stores"
+  paths     = ["${path.module}/data/stores.geojsonld"]
+}
+
+# CSV files with latitude and longitude columns are converted while they are uploaded.
+resource "mapbox_tileset_source" "cities" {
+  username  = "example"
+  source_id = "cities"
+  paths     = ["${path.module}/data/cities.csv"]
+}
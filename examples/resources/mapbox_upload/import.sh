@@ -0,0 +1,2 @@
+# Uploads can be imported using the username and the upload ID
+terraform import mapbox_upload.elevation example/cjx2hfy0o2ozx2ts4rqw6wdyb
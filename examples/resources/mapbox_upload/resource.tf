@@ -0,0 +1,10 @@
+resource "mapbox_upload" "elevation" {
+  username   = "example"
+  tileset_id = "example.elevation"
+  name       = "Elevation"
+  path       = "${path.module}/data/elevation.mbtiles"
+
+  timeouts = {
+    create = "2h"
+  }
+}
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var errAuditFindings = errors.New("audit reported findings")
+
+// publicScopes are the scopes a public (pk) token may carry, every other scope is a secret scope.
+var publicScopes = map[string]bool{
+	"styles:tiles":  true,
+	"styles:read":   true,
+	"fonts:read":    true,
+	"datasets:read": true,
+	"vision:read":   true,
+}
+
+const (
+	findingSecretScopesWithoutNote = "secret-scopes-without-note"
+	findingUnrestrictedPublicToken = "unrestricted-public-token"
+	findingStale                   = "stale"
+)
+
+// now is replaced in tests to get stable token ages.
+var now = time.Now
+
+// auditRecord is one row of the audit report.
+type auditRecord struct {
+	Id          string   `json:"id"`
+	Note        string   `json:"note"`
+	Usage       string   `json:"usage"`
+	Default     bool     `json:"default"`
+	Scopes      []string `json:"scopes"`
+	AllowedUrls []string `json:"allowed_urls"`
+	Created     string   `json:"created"`
+	AgeDays     int      `json:"age_days"`
+	Managed     *bool    `json:"managed"`
+	Findings    []string `json:"findings"`
+}
+
+// Audit reports every token of an account with its scopes, URL restrictions and age,
+// flags risky patterns and, when given a state file, whether Terraform manages it.
+func Audit(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("audit", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	username := flags.String("username", "", "username of the account to audit")
+	statePath := flags.String("state", "", "terraform state file (e.g. from `terraform state pull`) used to mark managed tokens")
+	format := flags.String("format", "table", "output format: table, json or csv")
+	maxAge := flags.Int("max-age", 365, "flag tokens older than this many days as stale, 0 disables the check")
+	failOnFindings := flags.Bool("fail-on-findings", false, "exit with an error when any token has findings")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		return errMissingUsername
+	}
+
+	write, ok := auditWriters[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q, expected one of: table, json, csv", *format)
+	}
+
+	var managed map[string]bool
+	if *statePath != "" {
+		var err error
+		managed, err = managedTokenIds(*statePath, *username)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	tokens, err := listTokens(client, *username)
+	if err != nil {
+		return fmt.Errorf("list tokens: %w", err)
+	}
+
+	records := make([]auditRecord, 0, len(tokens))
+	findings := false
+
+	for _, token := range tokens {
+		record := auditToken(token, *maxAge)
+
+		if managed != nil {
+			isManaged := managed[token.Id]
+			record.Managed = &isManaged
+		}
+
+		findings = findings || len(record.Findings) > 0
+		records = append(records, record)
+	}
+
+	if err := write(stdout, records); err != nil {
+		return err
+	}
+
+	if *failOnFindings && findings {
+		return errAuditFindings
+	}
+
+	return nil
+}
+
+func auditToken(token listedToken, maxAge int) auditRecord {
+	record := auditRecord{
+		Id:          token.Id,
+		Note:        token.Note,
+		Usage:       token.Usage,
+		Default:     token.Default,
+		Scopes:      token.Scopes,
+		AllowedUrls: token.AllowedUrls,
+		Created:     token.Created,
+		AgeDays:     -1,
+		Findings:    []string{},
+	}
+
+	if created, err := time.Parse(time.RFC3339, token.Created); err == nil {
+		record.AgeDays = int(now().Sub(created).Hours() / 24)
+	}
+
+	secret := false
+	for _, scope := range token.Scopes {
+		if !publicScopes[scope] {
+			secret = true
+			break
+		}
+	}
+
+	if secret && strings.TrimSpace(token.Note) == "" {
+		record.Findings = append(record.Findings, findingSecretScopesWithoutNote)
+	}
+
+	if !secret && len(token.AllowedUrls) == 0 {
+		record.Findings = append(record.Findings, findingUnrestrictedPublicToken)
+	}
+
+	if maxAge > 0 && record.AgeDays > maxAge {
+		record.Findings = append(record.Findings, findingStale)
+	}
+
+	return record
+}
+
+// managedTokenIds reads a terraform state file and returns the ids of the tokens of
+// username that are managed by mapbox_token resources.
+func managedTokenIds(statePath, username string) (map[string]bool, error) {
+	raw, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, fmt.Errorf("read state: %w", err)
+	}
+
+	var state struct {
+		Resources []struct {
+			Mode      string `json:"mode"`
+			Type      string `json:"type"`
+			Instances []struct {
+				Attributes struct {
+					Id string `json:"id"`
+				} `json:"attributes"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("decode state: %w", err)
+	}
+
+	managed := map[string]bool{}
+	for _, res := range state.Resources {
+		if res.Mode != "managed" || res.Type != "mapbox_token" {
+			continue
+		}
+
+		for _, instance := range res.Instances {
+			id, user, found := strings.Cut(instance.Attributes.Id, ":")
+			if found && user == username {
+				managed[id] = true
+			}
+		}
+	}
+
+	return managed, nil
+}
+
+var auditWriters = map[string]func(io.Writer, []auditRecord) error{
+	"table": writeAuditTable,
+	"json":  writeAuditJSON,
+	"csv":   writeAuditCSV,
+}
+
+func writeAuditJSON(w io.Writer, records []auditRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(records)
+}
+
+var auditColumns = []string{"id", "note", "usage", "scopes", "allowed_urls", "age_days", "managed", "findings"}
+
+func auditRow(record auditRecord) []string {
+	managed := "unknown"
+	if record.Managed != nil {
+		managed = strconv.FormatBool(*record.Managed)
+	}
+
+	return []string{
+		record.Id,
+		record.Note,
+		record.Usage,
+		strings.Join(record.Scopes, " "),
+		strings.Join(record.AllowedUrls, " "),
+		strconv.Itoa(record.AgeDays),
+		managed,
+		strings.Join(record.Findings, " "),
+	}
+}
+
+func writeAuditCSV(w io.Writer, records []auditRecord) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(auditColumns); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if err := cw.Write(auditRow(record)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeAuditTable(w io.Writer, records []auditRecord) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := make([]string, 0, len(auditColumns))
+	for _, column := range auditColumns {
+		header = append(header, strings.ToUpper(column))
+	}
+
+	if _, err := fmt.Fprintln(tw, strings.Join(header, "\t")); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		row := auditRow(record)
+		for i, cell := range row {
+			if cell == "" {
+				row[i] = "-"
+			}
+		}
+
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
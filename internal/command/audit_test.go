@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAudit_json(t *testing.T) {
+	t.Setenv("MAPBOX_ACCESS_TOKEN", "test-token")
+	defer gock.OffAll()
+
+	now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	gock.New("https://api.mapbox.com").
+		Get("tokens/v2/test-user").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON([]map[string]any{
+			{"id": "public", "note": "web", "usage": "pk", "scopes": []string{"styles:read"}, "created": "2025-12-01T00:00:00.000Z"},
+			{"id": "restricted", "note": "web", "usage": "pk", "scopes": []string{"styles:read"}, "allowedUrls": []string{"https://example.com"}, "created": "2025-12-01T00:00:00.000Z"},
+			{"id": "secret", "note": "", "usage": "sk", "scopes": []string{"styles:write"}, "created": "2020-01-01T00:00:00.000Z"},
+		})
+
+	state := filepath.Join(t.TempDir(), "terraform.tfstate")
+	err := os.WriteFile(state, []byte(`{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "mapbox_token", "name": "web", "instances": [{"attributes": {"id": "restricted:test-user"}}]},
+    {"mode": "managed", "type": "mapbox_token", "name": "other", "instances": [{"attributes": {"id": "public:other-user"}}]}
+  ]
+}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err = Audit(context.Background(), []string{"--username", "test-user", "--format", "json", "--state", state, "--fail-on-findings"}, &out)
+	if err != errAuditFindings {
+		t.Errorf("expected findings error, got: %v", err)
+	}
+
+	var records []auditRecord
+	if err := json.Unmarshal(out.Bytes(), &records); err != nil {
+		t.Fatalf("decode report: %s", err)
+	}
+
+	expected := map[string]struct {
+		findings string
+		managed  bool
+		age      int
+	}{
+		"public":     {findings: findingUnrestrictedPublicToken, age: 31},
+		"restricted": {findings: "", managed: true, age: 31},
+		"secret":     {findings: findingSecretScopesWithoutNote + " " + findingStale, age: 2192},
+	}
+
+	if len(records) != len(expected) {
+		t.Fatalf("expected %d records, got %d", len(expected), len(records))
+	}
+
+	for _, record := range records {
+		want := expected[record.Id]
+
+		if got := strings.Join(record.Findings, " "); got != want.findings {
+			t.Errorf("%s: expected findings %q, got %q", record.Id, want.findings, got)
+		}
+
+		if record.Managed == nil || *record.Managed != want.managed {
+			t.Errorf("%s: expected managed %t, got %v", record.Id, want.managed, record.Managed)
+		}
+
+		if record.AgeDays != want.age {
+			t.Errorf("%s: expected age %d, got %d", record.Id, want.age, record.AgeDays)
+		}
+	}
+}
+
+func TestAudit_csv(t *testing.T) {
+	t.Setenv("MAPBOX_ACCESS_TOKEN", "test-token")
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Get("tokens/v2/test-user").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON([]map[string]any{
+			{"id": "public", "note": "web, maps", "usage": "pk", "scopes": []string{"styles:read", "fonts:read"}, "allowedUrls": []string{"https://example.com"}},
+		})
+
+	var out bytes.Buffer
+	if err := Audit(context.Background(), []string{"--username", "test-user", "--format", "csv"}, &out); err != nil {
+		t.Fatalf("audit: %s", err)
+	}
+
+	expected := "id,note,usage,scopes,allowed_urls,age_days,managed,findings\n" +
+		"public,\"web, maps\",pk,styles:read fonts:read,https://example.com,-1,unknown,\n"
+
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
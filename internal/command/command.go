@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package command holds the helper subcommands that ship in the provider binary
+// next to the plugin server, e.g. `terraform-provider-mapbox generate-imports`.
+package command
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/drfaust92/terraform-provider-mapbox/internal/provider"
+)
+
+// Command is a subcommand of the provider binary.
+type Command func(ctx context.Context, args []string, stdout io.Writer) error
+
+var commands = map[string]Command{
+	"audit":            Audit,
+	"generate-imports": GenerateImports,
+}
+
+// Lookup returns the subcommand registered under name.
+func Lookup(name string) (Command, bool) {
+	cmd, ok := commands[name]
+	return cmd, ok
+}
+
+var errMissingAccessToken = errors.New("the MAPBOX_ACCESS_TOKEN environment variable is not set")
+
+// newClient builds a client the same way the provider does, from MAPBOX_ACCESS_TOKEN.
+func newClient() (*provider.Client, error) {
+	accessToken := os.Getenv("MAPBOX_ACCESS_TOKEN")
+	if accessToken == "" {
+		return nil, errMissingAccessToken
+	}
+
+	return &provider.Client{
+		AccessToken: &accessToken,
+		HTTPClient:  http.DefaultClient,
+	}, nil
+}
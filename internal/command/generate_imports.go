@@ -0,0 +1,382 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/drfaust92/terraform-provider-mapbox/internal/provider"
+)
+
+var errMissingUsername = errors.New("--username is required")
+
+// importer lists one kind of account object and turns every entry into an import block.
+type importer struct {
+	kind string
+	list func(client *provider.Client, username string) ([]importBlock, error)
+}
+
+// importers are run in order, so the generated file groups the objects by kind.
+var importers = []importer{
+	{kind: "tokens", list: listTokenImports},
+	{kind: "styles", list: listStyleImports},
+	{kind: "tilesets", list: listTilesetImports},
+	{kind: "datasets", list: listDatasetImports},
+}
+
+// importBlock is an `import {}` block together with the skeleton of the resource it imports into.
+type importBlock struct {
+	ResourceType string
+	Name         string
+	ID           string
+	Attributes   []hclAttribute
+}
+
+type hclAttribute struct {
+	Name  string
+	Value any
+}
+
+// GenerateImports lists the objects of a Mapbox account and writes `import {}` blocks
+// plus skeleton resource configurations with their current values. Secret values,
+// such as token strings, are never written.
+func GenerateImports(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("generate-imports", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	username := flags.String("username", "", "username of the account to generate import blocks for")
+	only := flags.String("only", "", "comma separated list of kinds to include ("+importerKinds()+"), defaults to all")
+	out := flags.String("out", "", "file to write the configuration to, defaults to stdout")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		return errMissingUsername
+	}
+
+	kinds, err := selectImporters(*only)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	var blocks []importBlock
+	for _, imp := range kinds {
+		found, err := imp.list(client, *username)
+		if err != nil {
+			return fmt.Errorf("list %s: %w", imp.kind, err)
+		}
+
+		blocks = append(blocks, found...)
+	}
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", *out, err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		w = f
+	}
+
+	return writeImportBlocks(w, blocks)
+}
+
+func importerKinds() string {
+	kinds := make([]string, 0, len(importers))
+	for _, imp := range importers {
+		kinds = append(kinds, imp.kind)
+	}
+
+	return strings.Join(kinds, ", ")
+}
+
+func selectImporters(only string) ([]importer, error) {
+	if only == "" {
+		return importers, nil
+	}
+
+	var selected []importer
+	for _, kind := range strings.Split(only, ",") {
+		kind = strings.TrimSpace(kind)
+
+		found := false
+		for _, imp := range importers {
+			if imp.kind == kind {
+				selected = append(selected, imp)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unknown kind %q, expected one of: %s", kind, importerKinds())
+		}
+	}
+
+	return selected, nil
+}
+
+// listedToken is the subset of the token listing the generator needs. The token value is
+// deliberately left out so it can never end up in the generated configuration.
+type listedToken struct {
+	Id          string   `json:"id"`
+	Note        string   `json:"note"`
+	Default     bool     `json:"default"`
+	Usage       string   `json:"usage"`
+	Scopes      []string `json:"scopes"`
+	AllowedUrls []string `json:"allowedUrls"`
+	Created     string   `json:"created"`
+	Modified    string   `json:"modified"`
+}
+
+func listTokens(client *provider.Client, username string) ([]listedToken, error) {
+	var tokens []listedToken
+
+	err := client.ListPages(fmt.Sprintf("tokens/v2/%s", username), func(body []byte) error {
+		var page []listedToken
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode tokens: %w", err)
+		}
+
+		tokens = append(tokens, page...)
+		return nil
+	})
+
+	return tokens, err
+}
+
+func listTokenImports(client *provider.Client, username string) ([]importBlock, error) {
+	tokens, err := listTokens(client, username)
+	if err != nil {
+		return nil, err
+	}
+
+	names := newNameSet()
+	blocks := make([]importBlock, 0, len(tokens))
+
+	for _, token := range tokens {
+		// The default public token is owned by the account and cannot be modified or deleted.
+		if token.Default {
+			continue
+		}
+
+		attributes := []hclAttribute{
+			{Name: "username", Value: username},
+			{Name: "note", Value: token.Note},
+			{Name: "scopes", Value: token.Scopes},
+		}
+
+		if len(token.AllowedUrls) > 0 {
+			attributes = append(attributes, hclAttribute{Name: "allowed_urls", Value: token.AllowedUrls})
+		}
+
+		blocks = append(blocks, importBlock{
+			ResourceType: "mapbox_token",
+			Name:         names.add(token.Note, "token"),
+			ID:           fmt.Sprintf("%s:%s", token.Id, username),
+			Attributes:   attributes,
+		})
+	}
+
+	return blocks, nil
+}
+
+type listedStyle struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func listStyleImports(client *provider.Client, username string) ([]importBlock, error) {
+	var styles []listedStyle
+
+	err := client.ListPages(fmt.Sprintf("styles/v1/%s", username), func(body []byte) error {
+		var page []listedStyle
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode styles: %w", err)
+		}
+
+		styles = append(styles, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := newNameSet()
+	blocks := make([]importBlock, 0, len(styles))
+
+	for _, style := range styles {
+		resp, err := client.Get(fmt.Sprintf("styles/v1/%s/%s", username, style.Id))
+		if err != nil {
+			return nil, fmt.Errorf("get style %s: %w", style.Id, err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read style %s: %w", style.Id, err)
+		}
+
+		document, err := provider.NormalizeStyleJSON(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("decode style %s: %w", style.Id, err)
+		}
+
+		blocks = append(blocks, importBlock{
+			ResourceType: "mapbox_style",
+			Name:         names.add(style.Name, "style"),
+			ID:           fmt.Sprintf("%s/%s", username, style.Id),
+			Attributes: []hclAttribute{
+				{Name: "username", Value: username},
+				{Name: "style", Value: hclHeredoc(document)},
+			},
+		})
+	}
+
+	return blocks, nil
+}
+
+type listedTileset struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+}
+
+func listTilesetImports(client *provider.Client, username string) ([]importBlock, error) {
+	var tilesets []listedTileset
+
+	err := client.ListPages(fmt.Sprintf("tilesets/v1/%s", username), func(body []byte) error {
+		var page []listedTileset
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode tilesets: %w", err)
+		}
+
+		tilesets = append(tilesets, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := newNameSet()
+	blocks := make([]importBlock, 0, len(tilesets))
+
+	for _, tileset := range tilesets {
+		resp, err := client.Get(fmt.Sprintf("tilesets/v1/%s/recipe", tileset.Id))
+
+		// Tilesets created by uploads have no recipe and cannot be managed as mapbox_tileset.
+		var apiErr provider.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get recipe of tileset %s: %w", tileset.Id, err)
+		}
+
+		var body struct {
+			Recipe json.RawMessage `json:"recipe"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode recipe of tileset %s: %w", tileset.Id, err)
+		}
+
+		var recipe bytes.Buffer
+		if err := json.Indent(&recipe, body.Recipe, "", "  "); err != nil {
+			return nil, fmt.Errorf("decode recipe of tileset %s: %w", tileset.Id, err)
+		}
+
+		attributes := []hclAttribute{
+			{Name: "tileset_id", Value: tileset.Id},
+			{Name: "name", Value: tileset.Name},
+		}
+
+		if tileset.Description != "" {
+			attributes = append(attributes, hclAttribute{Name: "description", Value: tileset.Description})
+		}
+
+		attributes = append(attributes,
+			hclAttribute{Name: "private", Value: tileset.Visibility != "public"},
+			hclAttribute{Name: "recipe", Value: hclHeredoc(recipe.String())},
+		)
+
+		blocks = append(blocks, importBlock{
+			ResourceType: "mapbox_tileset",
+			Name:         names.add(tileset.Name, "tileset"),
+			ID:           tileset.Id,
+			Attributes:   attributes,
+		})
+	}
+
+	return blocks, nil
+}
+
+type listedDataset struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func listDatasetImports(client *provider.Client, username string) ([]importBlock, error) {
+	var datasets []listedDataset
+
+	err := client.ListPages(fmt.Sprintf("datasets/v1/%s", username), func(body []byte) error {
+		var page []listedDataset
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode datasets: %w", err)
+		}
+
+		datasets = append(datasets, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := newNameSet()
+	blocks := make([]importBlock, 0, len(datasets))
+
+	for _, dataset := range datasets {
+		attributes := []hclAttribute{{Name: "username", Value: username}}
+
+		if dataset.Name != "" {
+			attributes = append(attributes, hclAttribute{Name: "name", Value: dataset.Name})
+		}
+
+		if dataset.Description != "" {
+			attributes = append(attributes, hclAttribute{Name: "description", Value: dataset.Description})
+		}
+
+		blocks = append(blocks, importBlock{
+			ResourceType: "mapbox_dataset",
+			Name:         names.add(dataset.Name, "dataset"),
+			ID:           fmt.Sprintf("%s/%s", username, dataset.Id),
+			Attributes:   attributes,
+		})
+	}
+
+	return blocks, nil
+}
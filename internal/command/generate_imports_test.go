@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestGenerateImports_tokens(t *testing.T) {
+	t.Setenv("MAPBOX_ACCESS_TOKEN", "test-token")
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Get("tokens/v2/test-user").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		SetHeader("Link", `<https://api.mapbox.com/tokens/v2/test-user?start=page2&access_token=test-token>; rel="next"`).
+		JSON([]map[string]any{
+			{"id": "default-id", "note": "Default public token", "default": true, "scopes": []string{"styles:tiles"}, "token": "pk.default"},
+			{"id": "id-1", "note": "CI ${deploy}", "scopes": []string{"styles:read"}, "allowedUrls": []string{"https://example.com"}, "token": "pk.secret1"},
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get("tokens/v2/test-user").
+		MatchParam("start", "page2").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON([]map[string]any{
+			{"id": "id-2", "note": "CI ${deploy}", "scopes": []string{"fonts:read"}, "token": "sk.secret2"},
+		})
+
+	var out bytes.Buffer
+	if err := GenerateImports(context.Background(), []string{"--username", "test-user", "--only", "tokens"}, &out); err != nil {
+		t.Fatalf("generate imports: %s", err)
+	}
+
+	expected := `import {
+  to = mapbox_token.ci_deploy
+  id = "id-1:test-user"
+}
+
+resource "mapbox_token" "ci_deploy" {
+  username     = "test-user"
+  note         = "CI $${deploy}"
+  scopes       = ["styles:read"]
+  allowed_urls = ["https://example.com"]
+}
+
+import {
+  to = mapbox_token.ci_deploy_2
+  id = "id-2:test-user"
+}
+
+resource "mapbox_token" "ci_deploy_2" {
+  username = "test-user"
+  note     = "CI $${deploy}"
+  scopes   = ["fonts:read"]
+}
+`
+
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	for _, secret := range []string{"pk.default", "pk.secret1", "sk.secret2"} {
+		if strings.Contains(out.String(), secret) {
+			t.Errorf("output contains token value %q", secret)
+		}
+	}
+
+	if !gock.IsDone() {
+		t.Errorf("not all mocked requests were made")
+	}
+}
+
+func TestGenerateImports_styles(t *testing.T) {
+	t.Setenv("MAPBOX_ACCESS_TOKEN", "test-token")
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Get("styles/v1/test-user").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON([]map[string]any{
+			{"id": "style-1", "name": "Streets", "owner": "test-user"},
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get("styles/v1/test-user/style-1").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{
+			"id":       "style-1",
+			"name":     "Streets",
+			"owner":    "test-user",
+			"created":  "2024-01-01T00:00:00.000Z",
+			"modified": "2024-01-01T00:00:00.000Z",
+			"version":  8,
+			"sources":  map[string]any{},
+			"layers":   []map[string]any{{"id": "label", "type": "symbol", "layout": map[string]any{"text-field": "${name}"}}},
+		})
+
+	var out bytes.Buffer
+	if err := GenerateImports(context.Background(), []string{"--username", "test-user", "--only", "styles"}, &out); err != nil {
+		t.Fatalf("generate imports: %s", err)
+	}
+
+	expected := `import {
+  to = mapbox_style.streets
+  id = "test-user/style-1"
+}
+
+resource "mapbox_style" "streets" {
+  username = "test-user"
+  style    = <<EOT
+{
+  "layers": [
+    {
+      "id": "label",
+      "layout": {
+        "text-field": "$${name}"
+      },
+      "type": "symbol"
+    }
+  ],
+  "name": "Streets",
+  "sources": {},
+  "version": 8
+}
+EOT
+}
+`
+
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestGenerateImports_tilesets(t *testing.T) {
+	t.Setenv("MAPBOX_ACCESS_TOKEN", "test-token")
+	defer gock.OffAll()
+
+	// The recipe mocks go first, the listing mock would match their paths too.
+	gock.New("https://api.mapbox.com").
+		Get("tilesets/v1/test-user.places/recipe").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{
+			"id":     "test-user.places",
+			"recipe": map[string]any{"version": 1, "layers": map[string]any{"places": map[string]any{"source": "mapbox://tileset-source/test-user/places", "minzoom": 0, "maxzoom": 10}}},
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get("tilesets/v1/test-user.elevation/recipe").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusNotFound).
+		JSON(map[string]any{"message": "Not Found"})
+
+	gock.New("https://api.mapbox.com").
+		Get("tilesets/v1/test-user").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON([]map[string]any{
+			{"id": "test-user.places", "name": "Places", "description": "Points of ${interest}", "visibility": "private", "type": "vector"},
+			{"id": "test-user.elevation", "name": "Elevation", "visibility": "public", "type": "raster"},
+		})
+
+	var out bytes.Buffer
+	if err := GenerateImports(context.Background(), []string{"--username", "test-user", "--only", "tilesets"}, &out); err != nil {
+		t.Fatalf("generate imports: %s", err)
+	}
+
+	expected := `import {
+  to = mapbox_tileset.places
+  id = "test-user.places"
+}
+
+resource "mapbox_tileset" "places" {
+  tileset_id  = "test-user.places"
+  name        = "Places"
+  description = "Points of $${interest}"
+  private     = true
+  recipe      = <<EOT
+{
+  "layers": {
+    "places": {
+      "maxzoom": 10,
+      "minzoom": 0,
+      "source": "mapbox://tileset-source/test-user/places"
+    }
+  },
+  "version": 1
+}
+EOT
+}
+`
+
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestGenerateImports_datasets(t *testing.T) {
+	t.Setenv("MAPBOX_ACCESS_TOKEN", "test-token")
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Get("datasets/v1/test-user").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON([]map[string]any{
+			{"id": "cjz5g2fue0ed61cp6wy7tn1xe", "owner": "test-user", "name": "Stores", "description": "Store locations", "features": 3},
+			{"id": "ck0a1b2c3d4e5f6g7h8i9j0kl", "owner": "test-user", "features": 0},
+		})
+
+	var out bytes.Buffer
+	if err := GenerateImports(context.Background(), []string{"--username", "test-user", "--only", "datasets"}, &out); err != nil {
+		t.Fatalf("generate imports: %s", err)
+	}
+
+	expected := `import {
+  to = mapbox_dataset.stores
+  id = "test-user/cjz5g2fue0ed61cp6wy7tn1xe"
+}
+
+resource "mapbox_dataset" "stores" {
+  username    = "test-user"
+  name        = "Stores"
+  description = "Store locations"
+}
+
+import {
+  to = mapbox_dataset.dataset
+  id = "test-user/ck0a1b2c3d4e5f6g7h8i9j0kl"
+}
+
+resource "mapbox_dataset" "dataset" {
+  username = "test-user"
+}
+`
+
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestGenerateImports_missingUsername(t *testing.T) {
+	err := GenerateImports(context.Background(), nil, &bytes.Buffer{})
+	if err != errMissingUsername {
+		t.Errorf("expected missing username error, got: %v", err)
+	}
+}
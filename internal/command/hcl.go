@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func writeImportBlocks(w io.Writer, blocks []importBlock) error {
+	var sb strings.Builder
+
+	for i, block := range blocks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		fmt.Fprintf(&sb, "import {\n  to = %s.%s\n  id = %s\n}\n\n", block.ResourceType, block.Name, hclString(block.ID))
+		fmt.Fprintf(&sb, "resource %q %q {\n", block.ResourceType, block.Name)
+
+		width := 0
+		for _, attr := range block.Attributes {
+			width = max(width, len(attr.Name))
+		}
+
+		for _, attr := range block.Attributes {
+			fmt.Fprintf(&sb, "  %-*s = %s\n", width, attr.Name, hclValue(attr.Value))
+		}
+
+		sb.WriteString("}\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func hclValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return hclString(v)
+	case []string:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, hclString(item))
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case hclHeredoc:
+		return "<<EOT\n" + hclEscapeTemplate(string(v)) + "\nEOT"
+	default:
+		return hclString(fmt.Sprint(v))
+	}
+}
+
+// hclHeredoc is written as a heredoc string, used for multi-line documents such as style JSON.
+type hclHeredoc string
+
+func hclEscapeTemplate(s string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences so
+// values are never interpolated.
+func hclString(s string) string {
+	var sb strings.Builder
+
+	sb.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"':
+			sb.WriteString(`\"`)
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			sb.WriteRune(r)
+			sb.WriteRune(r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// nameSet hands out unique resource names derived from human readable labels.
+type nameSet map[string]bool
+
+func newNameSet() nameSet {
+	return nameSet{}
+}
+
+func (n nameSet) add(label, fallback string) string {
+	var sb strings.Builder
+	underscore := false
+
+	for _, r := range strings.ToLower(label) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+			underscore = false
+		} else if !underscore && sb.Len() > 0 {
+			sb.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	name := strings.TrimSuffix(sb.String(), "_")
+	if name == "" {
+		name = fallback
+	} else if !unicode.IsLetter(rune(name[0])) {
+		name = fallback + "_" + name
+	}
+
+	base := name
+	for i := 2; n[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	n[name] = true
+
+	return name
+}
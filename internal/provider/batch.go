@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// runBatch calls fn for every name with at most parallelism calls in flight, and returns
+// the names it succeeded for along with the joined errors of the others, labelled with kind.
+func runBatch(kind string, names []string, parallelism int, fn func(name string) error) ([]string, error) {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done []string
+		errs []error
+	)
+
+	sem := make(chan struct{}, parallelism)
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := fn(name)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s %q: %w", kind, name, err))
+				return
+			}
+			done = append(done, name)
+		}(name)
+	}
+	wg.Wait()
+
+	sort.Strings(done)
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+
+	return done, errors.Join(errs...)
+}
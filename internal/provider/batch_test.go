@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunBatch(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	names := []string{"a", "b", "c", "d", "e", "f", "g"}
+	done, err := runBatch("icon", names, 2, func(name string) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			peak := atomic.LoadInt32(&maxInFlight)
+			if n <= peak || atomic.CompareAndSwapInt32(&maxInFlight, peak, n) {
+				break
+			}
+		}
+
+		if name == "c" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 calls in flight, got %d", maxInFlight)
+	}
+	if !reflect.DeepEqual(done, []string{"a", "b", "d", "e", "f", "g"}) {
+		t.Errorf("unexpected names: %v", done)
+	}
+	if err == nil || err.Error() != `icon "c": failed` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -2,11 +2,16 @@ package provider
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 )
 
 // Error represents a error from the bitbucket api.
@@ -33,16 +38,28 @@ const (
 type Client struct {
 	AccessToken *string
 	HTTPClient  *http.Client
+	// S3Endpoint replaces Amazon S3 for the staging bucket of the Uploads API when set.
+	S3Endpoint string
 }
 
 var errNoResponse = errors.New("no response returned from API")
 
 // Do Will just call the bitbucket api but also add auth to it and some extra headers
 func (c *Client) Do(method, endpoint string, payload *bytes.Buffer, contentType string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = payload
+	}
+
+	return c.DoReader(method, endpoint, body, contentType)
+}
+
+// DoReader is Do for bodies that are not JSON buffers, e.g. SVG icons or files streamed from disk
+func (c *Client) DoReader(method, endpoint string, body io.Reader, contentType string) (*http.Response, error) {
 	absoluteendpoint := MapBoxEndpoint + endpoint
 
 	client := c.httpClient()
-	req, err := c.buildRequest(method, absoluteendpoint, payload, contentType)
+	req, err := c.buildRequest(method, absoluteendpoint, body, contentType)
 	if err != nil {
 		return nil, err
 	}
@@ -71,13 +88,8 @@ func (c *Client) httpClient() *http.Client {
 	return http.DefaultClient
 }
 
-func (c *Client) buildRequest(method, absoluteendpoint string, payload *bytes.Buffer, contentType string) (*http.Request, error) {
-	var bodyreader io.Reader
-	if payload != nil {
-		bodyreader = payload
-	}
-
-	req, err := http.NewRequest(method, absoluteendpoint, bodyreader)
+func (c *Client) buildRequest(method, absoluteendpoint string, body io.Reader, contentType string) (*http.Request, error) {
+	req, err := http.NewRequest(method, absoluteendpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -88,7 +100,7 @@ func (c *Client) buildRequest(method, absoluteendpoint string, payload *bytes.Bu
 		req.URL.RawQuery = q.Encode()
 	}
 
-	if payload != nil && contentType != "" {
+	if body != nil && contentType != "" {
 		req.Header.Add("Content-Type", contentType)
 	}
 
@@ -148,7 +160,145 @@ func (c *Client) PutOnly(endpoint string) (*http.Response, error) {
 	return c.Do("PUT", endpoint, nil, "application/json")
 }
 
+// PostBody is just a helper method to do but with a POST verb and a body of the given content type
+func (c *Client) PostBody(endpoint string, body io.Reader, contentType string) (*http.Response, error) {
+	return c.DoReader("POST", endpoint, body, contentType)
+}
+
+// PutBody is just a helper method to do but with a PUT verb and a body of the given content type
+func (c *Client) PutBody(endpoint string, body io.Reader, contentType string) (*http.Response, error) {
+	return c.DoReader("PUT", endpoint, body, contentType)
+}
+
+// DoMultipart sends content as the file field of a multipart form. The form is streamed
+// to the API as it is written, so large files are never held in memory.
+func (c *Client) DoMultipart(method, endpoint, fileName string, content io.Reader) (*http.Response, error) {
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+
+	go func() {
+		part, err := form.CreateFormFile("file", fileName)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	resp, err := c.DoReader(method, endpoint, pr, form.FormDataContentType())
+
+	// Unblocks the writer when the request failed before the body was read to the end.
+	_ = pr.Close()
+
+	return resp, err
+}
+
 // Delete is just a helper to Do but with a DELETE verb
 func (c *Client) Delete(endpoint string) (*http.Response, error) {
 	return c.Do("DELETE", endpoint, nil, "application/json")
 }
+
+// decodeJSON reads the body of resp into v and closes it.
+func decodeJSON(resp *http.Response, v any) error {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// isNotFound reports whether err is an API error for an object that no longer exists.
+func isNotFound(err error) bool {
+	var apiErr Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+var linkNextRe = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// ListPages will GET the endpoint and follow the Link headers returned by list endpoints,
+// handing the body of every page to the callback until there are no more pages
+func (c *Client) ListPages(endpoint string, page func(body []byte) error) error {
+	for endpoint != "" {
+		resp, err := c.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("read response: %w", err)
+		}
+
+		if err := page(body); err != nil {
+			return err
+		}
+
+		endpoint = nextPage(resp.Header.Get("Link"))
+	}
+
+	return nil
+}
+
+// nextPage turns the rel="next" entry of a Link header into an endpoint relative to MapBoxEndpoint.
+// The access token is dropped from the query since buildRequest adds it again.
+func nextPage(link string) string {
+	match := linkNextRe.FindStringSubmatch(link)
+	if match == nil {
+		return ""
+	}
+
+	next, err := url.Parse(match[1])
+	if err != nil {
+		return ""
+	}
+
+	q := next.Query()
+	q.Del("access_token")
+	next.RawQuery = q.Encode()
+
+	endpoint := strings.TrimPrefix(next.Path, "/")
+	if next.RawQuery != "" {
+		endpoint += "?" + next.RawQuery
+	}
+
+	return endpoint
+}
+
+// tokenUsername returns the username of the account the access token belongs to, which
+// Mapbox tokens carry in the base64 encoded payload between the first two dots.
+func (c *Client) tokenUsername() (string, error) {
+	if c.AccessToken == nil {
+		return "", errors.New("no access token configured")
+	}
+
+	parts := strings.Split(*c.AccessToken, ".")
+	if len(parts) != 3 {
+		return "", errors.New("the access token is not a Mapbox token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return "", fmt.Errorf("decode access token: %w", err)
+	}
+
+	var claims struct {
+		Username string `json:"u"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Username == "" {
+		return "", errors.New("the access token has no username")
+	}
+
+	return claims.Username, nil
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestTokenUsername(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"u":"example","a":"cjz5g2fue0ed61cp6wy7tn1xe"}`))
+
+	cases := map[string]string{
+		"pk." + payload + ".signature": "example",
+		"sk." + payload + ".signature": "example",
+		"test-token":                   "",
+		"pk.not-base64!.signature":     "",
+		"pk." + base64.RawURLEncoding.EncodeToString([]byte(`{"a":"b"}`)) + ".signature": "",
+	}
+
+	for token, expected := range cases {
+		client := &Client{AccessToken: &token}
+
+		username, err := client.tokenUsername()
+		if username != expected || (expected == "") != (err != nil) {
+			t.Errorf("tokenUsername() for %q = %q, %v, expected %q", token, username, err, expected)
+		}
+	}
+}
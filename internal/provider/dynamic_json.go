@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// dynamicToJSON converts a dynamic attribute value, such as an HCL object of paint properties,
+// into the value encoding/json would decode from the equivalent JSON document.
+func dynamicToJSON(value attr.Value) (any, error) {
+	if value == nil || value.IsNull() {
+		return nil, nil
+	}
+
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return dynamicToJSON(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.NumberValue:
+		f, _ := v.ValueBigFloat().Float64()
+		return f, nil
+	case basetypes.Int64Value:
+		return float64(v.ValueInt64()), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	case basetypes.ObjectValue:
+		return dynamicMapToJSON(v.Attributes())
+	case basetypes.MapValue:
+		return dynamicMapToJSON(v.Elements())
+	case basetypes.TupleValue:
+		return dynamicListToJSON(v.Elements())
+	case basetypes.ListValue:
+		return dynamicListToJSON(v.Elements())
+	case basetypes.SetValue:
+		return dynamicListToJSON(v.Elements())
+	}
+
+	return nil, fmt.Errorf("unsupported value type %s", value.Type(context.Background()))
+}
+
+func dynamicMapToJSON(elements map[string]attr.Value) (any, error) {
+	out := make(map[string]any, len(elements))
+
+	for key, element := range elements {
+		value, err := dynamicToJSON(element)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		out[key] = value
+	}
+
+	return out, nil
+}
+
+func dynamicListToJSON(elements []attr.Value) (any, error) {
+	out := make([]any, 0, len(elements))
+
+	for i, element := range elements {
+		value, err := dynamicToJSON(element)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+
+		out = append(out, value)
+	}
+
+	return out, nil
+}
+
+// jsonToDynamic is the reverse of dynamicToJSON, objects become HCL objects and arrays tuples.
+func jsonToDynamic(value any) types.Dynamic {
+	if value == nil {
+		return types.DynamicNull()
+	}
+
+	return types.DynamicValue(jsonToValue(value))
+}
+
+func jsonToValue(value any) attr.Value {
+	switch v := value.(type) {
+	case string:
+		return types.StringValue(v)
+	case bool:
+		return types.BoolValue(v)
+	case float64:
+		return types.NumberValue(big.NewFloat(v))
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrValues := make(map[string]attr.Value, len(v))
+		for key, item := range v {
+			element := jsonToValue(item)
+			attrTypes[key] = element.Type(context.Background())
+			attrValues[key] = element
+		}
+
+		return types.ObjectValueMust(attrTypes, attrValues)
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elemValues := make([]attr.Value, 0, len(v))
+		for _, item := range v {
+			element := jsonToValue(item)
+			elemTypes = append(elemTypes, element.Type(context.Background()))
+			elemValues = append(elemValues, element)
+		}
+
+		return types.TupleValueMust(elemTypes, elemValues)
+	}
+
+	return types.DynamicNull()
+}
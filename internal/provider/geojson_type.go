@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// coordinateTolerance is the difference in degrees below which coordinates are considered
+// equal, about a centimeter at the equator. The Datasets API stores coordinates with fewer
+// digits than Terraform configurations may use.
+const coordinateTolerance = 1e-7
+
+var (
+	_ basetypes.StringTypable                    = GeoJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = GeoJSON{}
+	_ xattr.ValidateableAttribute                = GeoJSON{}
+)
+
+// GeoJSONType is the attribute type of GeoJSON objects such as geometries and feature
+// properties. Its values are semantically equal when they only differ in key order, number
+// formatting or geometry coordinates within coordinateTolerance.
+type GeoJSONType struct {
+	basetypes.StringType
+}
+
+func (t GeoJSONType) String() string {
+	return "provider.GeoJSONType"
+}
+
+func (t GeoJSONType) ValueType(ctx context.Context) attr.Value {
+	return GeoJSON{}
+}
+
+func (t GeoJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(GeoJSONType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t GeoJSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return GeoJSON{StringValue: in}, nil
+}
+
+func (t GeoJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return GeoJSON{StringValue: stringValue}, nil
+}
+
+// GeoJSON is a GeoJSON object value.
+type GeoJSON struct {
+	basetypes.StringValue
+}
+
+func NewGeoJSONValue(value string) GeoJSON {
+	return GeoJSON{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v GeoJSON) Type(ctx context.Context) attr.Type {
+	return GeoJSONType{}
+}
+
+func (v GeoJSON) Equal(o attr.Value) bool {
+	other, ok := o.(GeoJSON)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals keeps the prior object in state when Mapbox returns an equivalent one.
+func (v GeoJSON) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(GeoJSON)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	return geoJSONEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+func (v GeoJSON) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal([]byte(v.ValueString()), &object); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid GeoJSON", fmt.Sprintf("The value must be a JSON object, got error: %s", err))
+	}
+}
+
+// geoJSONEqual reports whether two GeoJSON documents are equal, comparing the coordinates of
+// geometries to coordinateTolerance and everything else exactly.
+func geoJSONEqual(a, b string) bool {
+	var valueA, valueB any
+
+	if err := json.Unmarshal([]byte(a), &valueA); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(b), &valueB); err != nil {
+		return false
+	}
+
+	return geoJSONValuesEqual(valueA, valueB, false)
+}
+
+// geoJSONValuesEqual compares decoded JSON values, numbers within coordinateTolerance when
+// they are coordinates of a geometry.
+func geoJSONValuesEqual(a, b any, coordinates bool) bool {
+	switch valueA := a.(type) {
+	case map[string]any:
+		valueB, ok := b.(map[string]any)
+		if !ok || len(valueA) != len(valueB) {
+			return false
+		}
+
+		_, geometry := geometryTypes[fmt.Sprint(valueA["type"])]
+
+		for key, memberA := range valueA {
+			memberB, ok := valueB[key]
+			if !ok || !geoJSONValuesEqual(memberA, memberB, geometry && key == "coordinates") {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		valueB, ok := b.([]any)
+		if !ok || len(valueA) != len(valueB) {
+			return false
+		}
+
+		for i := range valueA {
+			if !geoJSONValuesEqual(valueA[i], valueB[i], coordinates) {
+				return false
+			}
+		}
+
+		return true
+	case float64:
+		valueB, ok := b.(float64)
+		if !ok {
+			return false
+		}
+
+		if coordinates {
+			return math.Abs(valueA-valueB) <= coordinateTolerance
+		}
+
+		return valueA == valueB
+	}
+
+	return a == b
+}
+
+// geometryTypes are the GeoJSON geometry types with coordinates.
+var geometryTypes = map[string]struct{}{
+	"Point":           {},
+	"MultiPoint":      {},
+	"LineString":      {},
+	"MultiLineString": {},
+	"Polygon":         {},
+	"MultiPolygon":    {},
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// validateWinding checks that polygons of a valid GeoJSON geometry follow the right-hand rule
+// of RFC 7946: exterior rings are counterclockwise and holes are clockwise. Parsers should not
+// reject other polygons and Mapbox accepts them, so callers report the error as a warning.
+func validateWinding(content json.RawMessage) error {
+	var geometry struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometries  []json.RawMessage `json:"geometries"`
+	}
+
+	if err := json.Unmarshal(content, &geometry); err != nil {
+		return fmt.Errorf("invalid geometry: %w", err)
+	}
+
+	var polygons [][][][]float64
+
+	switch geometry.Type {
+	case "GeometryCollection":
+		for i, member := range geometry.Geometries {
+			if err := validateWinding(member); err != nil {
+				return fmt.Errorf("geometry %d: %w", i+1, err)
+			}
+		}
+
+		return nil
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &rings); err != nil {
+			return fmt.Errorf("invalid %s: %w", geometry.Type, err)
+		}
+		polygons = append(polygons, rings)
+	case "MultiPolygon":
+		if err := json.Unmarshal(geometry.Coordinates, &polygons); err != nil {
+			return fmt.Errorf("invalid %s: %w", geometry.Type, err)
+		}
+	}
+
+	for _, rings := range polygons {
+		for i, ring := range rings {
+			area := ringArea(ring)
+
+			if i == 0 && area < 0 {
+				return fmt.Errorf("the exterior ring of the %s is clockwise, expected counterclockwise", geometry.Type)
+			}
+
+			if i > 0 && area > 0 {
+				return fmt.Errorf("hole %d of the %s is counterclockwise, expected clockwise", i, geometry.Type)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidateWinding(t *testing.T) {
+	cases := map[string]string{
+		`{"type": "Point", "coordinates": [0, 0]}`:                                                                               "",
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`:                                                 "",
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 1], [1, 0], [0, 0]]]}`:                                                 "the exterior ring of the Polygon is clockwise, expected counterclockwise",
+		`{"type": "Polygon", "coordinates": [[[0, 0], [4, 0], [4, 4], [0, 0]], [[2, 1], [3, 1], [3, 2], [2, 1]]]}`:               "hole 1 of the Polygon is counterclockwise, expected clockwise",
+		`{"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, 1], [0, 0]]], [[[0, 0], [1, 1], [1, 0], [0, 0]]]]}`:      "the exterior ring of the MultiPolygon is clockwise, expected counterclockwise",
+		`{"type": "GeometryCollection", "geometries": [{"type": "Polygon", "coordinates": [[[0, 0], [1, 1], [1, 0], [0, 0]]]}]}`: "geometry 1: the exterior ring of the Polygon is clockwise, expected counterclockwise",
+	}
+
+	for geometry, expected := range cases {
+		err := validateWinding([]byte(geometry))
+
+		if actual := fmt.Sprint(err); (expected == "" && err != nil) || (expected != "" && actual != expected) {
+			t.Errorf("validateWinding(%q) = %v, expected %q", geometry, err, expected)
+		}
+	}
+}
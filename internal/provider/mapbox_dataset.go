@@ -0,0 +1,339 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatasetResource{}
+var _ resource.ResourceWithImportState = &DatasetResource{}
+
+func NewDatasetResource() resource.Resource {
+	return &DatasetResource{}
+}
+
+// DatasetResource defines the resource implementation.
+type DatasetResource struct {
+	client *Client
+}
+
+// DatasetResourceModel describes the resource data model.
+type DatasetResourceModel struct {
+	Bounds      types.List   `tfsdk:"bounds"`
+	Created     types.String `tfsdk:"created"`
+	Description types.String `tfsdk:"description"`
+	Features    types.Int64  `tfsdk:"features"`
+	Id          types.String `tfsdk:"id"`
+	Modified    types.String `tfsdk:"modified"`
+	Name        types.String `tfsdk:"name"`
+	Size        types.Int64  `tfsdk:"size"`
+	Username    types.String `tfsdk:"username"`
+}
+
+// dataset is a dataset as returned by the Datasets API.
+type dataset struct {
+	Bounds      []float64 `json:"bounds"`
+	Created     string    `json:"created"`
+	Description string    `json:"description"`
+	Features    int64     `json:"features"`
+	Id          string    `json:"id"`
+	Modified    string    `json:"modified"`
+	Name        string    `json:"name"`
+	Owner       string    `json:"owner"`
+	Size        int64     `json:"size"`
+}
+
+func (r *DatasetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset"
+}
+
+func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a dataset of the Mapbox Datasets API, a collection of editable GeoJSON features. Datasets are imported by their ID when they belong to the account of the access token, or as `USERNAME/DATASET-ID`.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the dataset.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the dataset.",
+				Optional:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of the dataset.",
+				Optional:            true,
+			},
+			"features": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of features in the dataset.",
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the dataset in bytes.",
+			},
+			"bounds": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.Float64Type,
+				MarkdownDescription: "The extent of the features as west, south, east and north in degrees, empty while the dataset has no features.",
+			},
+			"created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the dataset was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the dataset was last modified.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the dataset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DatasetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DatasetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatasetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	bytedata, err := json.Marshal(data.info())
+	if err != nil {
+		resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Unable to encode dataset, got error: %s", err))
+		return
+	}
+
+	createResp, err := r.client.Post(datasetsEndpoint(data.Username.ValueString()), bytes.NewBuffer(bytedata))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create dataset, got error: %s", err))
+		return
+	}
+
+	var created dataset
+	if err := decodeJSON(createResp, &created); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create dataset, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.setDataset(ctx, created)...)
+
+	tflog.Trace(ctx, "created a dataset", map[string]any{"id": data.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatasetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := getDataset(r.client, data.Username.ValueString(), data.Id.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "dataset not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset, got error: %s", err))
+		return
+	}
+
+	// Unset names and descriptions are returned empty, which keeps them unset.
+	if current.Name != "" || !data.Name.IsNull() {
+		data.Name = types.StringValue(current.Name)
+	}
+
+	if current.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(current.Description)
+	}
+
+	resp.Diagnostics.Append(data.setDataset(ctx, current)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DatasetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bytedata, err := json.Marshal(data.info())
+	if err != nil {
+		resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Unable to encode dataset, got error: %s", err))
+		return
+	}
+
+	updateResp, err := r.client.Patch(datasetEndpoint(data.Username.ValueString(), data.Id.ValueString()), bytes.NewBuffer(bytedata))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update dataset, got error: %s", err))
+		return
+	}
+
+	var updated dataset
+	if err := decodeJSON(updateResp, &updated); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update dataset, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.setDataset(ctx, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatasetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.Delete(datasetEndpoint(data.Username.ValueString(), data.Id.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete dataset, got error: %s", err))
+		return
+	}
+
+	_ = httpResp.Body.Close()
+}
+
+func (r *DatasetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	username, datasetId, found := strings.Cut(req.ID, "/")
+
+	// A dataset ID alone belongs to the account of the access token.
+	if !found && r.client != nil {
+		datasetId = req.ID
+
+		var err error
+		if username, err = r.client.tokenUsername(); err != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("unable to find the owner of dataset %q, import it as USERNAME/DATASET-ID instead: %s", req.ID, err),
+			)
+			return
+		}
+	}
+
+	if username == "" || datasetId == "" || strings.Contains(datasetId, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("unexpected format of ID (%q), expected DATASET-ID or USERNAME/DATASET-ID", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), username)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), datasetId)...)
+}
+
+// info returns the name and description to save, unset values are saved empty to clear them.
+func (data DatasetResourceModel) info() map[string]any {
+	return map[string]any{
+		"name":        data.Name.ValueString(),
+		"description": data.Description.ValueString(),
+	}
+}
+
+// setDataset sets the computed attributes from a dataset returned by the API.
+func (data *DatasetResourceModel) setDataset(ctx context.Context, current dataset) diag.Diagnostics {
+	data.Id = types.StringValue(current.Id)
+	data.Created = types.StringValue(current.Created)
+	data.Modified = types.StringValue(current.Modified)
+	data.Features = types.Int64Value(current.Features)
+	data.Size = types.Int64Value(current.Size)
+
+	bounds := current.Bounds
+	if bounds == nil {
+		bounds = []float64{}
+	}
+
+	var diags diag.Diagnostics
+	data.Bounds, diags = types.ListValueFrom(ctx, types.Float64Type, bounds)
+
+	return diags
+}
+
+func getDataset(client *Client, username, id string) (dataset, error) {
+	var current dataset
+
+	resp, err := client.Get(datasetEndpoint(username, id))
+	if err != nil {
+		return current, err
+	}
+
+	err = decodeJSON(resp, &current)
+	return current, err
+}
+
+func datasetsEndpoint(username string) string {
+	return fmt.Sprintf("datasets/v1/%s", username)
+}
+
+func datasetEndpoint(username, id string) string {
+	return fmt.Sprintf("datasets/v1/%s/%s", username, id)
+}
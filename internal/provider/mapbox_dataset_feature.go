@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatasetFeatureResource{}
+var _ resource.ResourceWithImportState = &DatasetFeatureResource{}
+var _ resource.ResourceWithValidateConfig = &DatasetFeatureResource{}
+
+func NewDatasetFeatureResource() resource.Resource {
+	return &DatasetFeatureResource{}
+}
+
+// DatasetFeatureResource defines the resource implementation.
+type DatasetFeatureResource struct {
+	client *Client
+}
+
+// DatasetFeatureResourceModel describes the resource data model.
+type DatasetFeatureResourceModel struct {
+	DatasetId  types.String `tfsdk:"dataset_id"`
+	FeatureId  types.String `tfsdk:"feature_id"`
+	Geometry   GeoJSON      `tfsdk:"geometry"`
+	Id         types.String `tfsdk:"id"`
+	Properties GeoJSON      `tfsdk:"properties"`
+	Username   types.String `tfsdk:"username"`
+}
+
+// datasetFeature is a feature as stored by the Datasets API.
+type datasetFeature struct {
+	Geometry   json.RawMessage `json:"geometry"`
+	Id         string          `json:"id"`
+	Properties json.RawMessage `json:"properties"`
+	Type       string          `json:"type"`
+}
+
+func (r *DatasetFeatureResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_feature"
+}
+
+func (r *DatasetFeatureResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single GeoJSON feature of a dataset. Geometries are validated during plan, and coordinates returned by the API that differ from the configuration by less than `1e-7` degrees do not cause a diff.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the dataset.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dataset_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the dataset.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"feature_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the feature, unique within the dataset.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"geometry": schema.StringAttribute{
+				MarkdownDescription: "The GeoJSON geometry of the feature as JSON, for example with `jsonencode`. Positions must be within the longitude and latitude range, polygon rings must be closed and should follow the right-hand rule: exterior rings counterclockwise, holes clockwise.",
+				Required:            true,
+				CustomType:          GeoJSONType{},
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "The properties of the feature as a JSON object.",
+				Optional:            true,
+				CustomType:          GeoJSONType{},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the feature in the form `USERNAME/DATASET-ID/FEATURE-ID`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DatasetFeatureResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DatasetFeatureResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DatasetFeatureResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Geometry.IsNull() || data.Geometry.IsUnknown() {
+		return
+	}
+
+	geometry := json.RawMessage(data.Geometry.ValueString())
+
+	if err := validateGeometry(geometry); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("geometry"), "Invalid Geometry", fmt.Sprintf("The geometry is not valid GeoJSON: %s.", err))
+		return
+	}
+
+	if err := validateWinding(geometry); err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("geometry"), "Polygon Winding", fmt.Sprintf("The geometry does not follow the right-hand rule of RFC 7946: %s.", err))
+	}
+}
+
+func (r *DatasetFeatureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatasetFeatureResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	if err := r.put(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create dataset feature, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", data.Username.ValueString(), data.DatasetId.ValueString(), data.FeatureId.ValueString()))
+
+	tflog.Trace(ctx, "created a dataset feature", map[string]any{"id": data.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetFeatureResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatasetFeatureResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	feature, err := getDatasetFeature(r.client, data.Username.ValueString(), data.DatasetId.ValueString(), data.FeatureId.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "dataset feature not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset feature, got error: %s", err))
+		return
+	}
+
+	data.Geometry = NewGeoJSONValue(string(feature.Geometry))
+
+	if len(feature.Properties) == 0 || string(feature.Properties) == "null" {
+		feature.Properties = json.RawMessage("{}")
+	}
+
+	// Features without properties are returned with an empty object, which keeps them unset.
+	if !data.Properties.IsNull() || !geoJSONEqual(string(feature.Properties), "{}") {
+		data.Properties = NewGeoJSONValue(string(feature.Properties))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetFeatureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DatasetFeatureResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.put(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update dataset feature, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetFeatureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatasetFeatureResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.Delete(datasetFeatureEndpoint(data.Username.ValueString(), data.DatasetId.ValueString(), data.FeatureId.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete dataset feature, got error: %s", err))
+		return
+	}
+
+	_ = httpResp.Body.Close()
+}
+
+func (r *DatasetFeatureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("unexpected format of ID (%q), expected USERNAME/DATASET-ID/FEATURE-ID", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dataset_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("feature_id"), parts[2])...)
+}
+
+// put inserts or replaces the feature in the dataset.
+func (r *DatasetFeatureResource) put(data *DatasetFeatureResourceModel) error {
+	properties := json.RawMessage("{}")
+	if !data.Properties.IsNull() {
+		properties = json.RawMessage(data.Properties.ValueString())
+	}
+
+	bytedata, err := json.Marshal(datasetFeature{
+		Geometry:   json.RawMessage(data.Geometry.ValueString()),
+		Id:         data.FeatureId.ValueString(),
+		Properties: properties,
+		Type:       "Feature",
+	})
+	if err != nil {
+		return fmt.Errorf("encode feature: %w", err)
+	}
+
+	resp, err := r.client.Put(datasetFeatureEndpoint(data.Username.ValueString(), data.DatasetId.ValueString(), data.FeatureId.ValueString()), bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func getDatasetFeature(client *Client, username, datasetId, featureId string) (datasetFeature, error) {
+	var feature datasetFeature
+
+	resp, err := client.Get(datasetFeatureEndpoint(username, datasetId, featureId))
+	if err != nil {
+		return feature, err
+	}
+
+	err = decodeJSON(resp, &feature)
+	return feature, err
+}
+
+func datasetFeatureEndpoint(username, datasetId, featureId string) string {
+	return fmt.Sprintf("%s/features/%s", datasetEndpoint(username, datasetId), url.PathEscape(featureId))
+}
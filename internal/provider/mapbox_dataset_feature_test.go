@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccDatasetFeatureResource_basic(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("dataset features are only tested against mocks")
+	}
+
+	resourceName := "mapbox_dataset_feature.test"
+	username := "test"
+	datasetId := "cjz5g2fue0ed61cp6wy7tn1xe"
+	endpoint := fmt.Sprintf("datasets/v1/%s/%s/features/store-1", username, datasetId)
+
+	// The API stores fewer digits and returns the members in its own order.
+	var saved string
+	current := func(name string) string {
+		return fmt.Sprintf(`{"properties": {"name": %q}, "id": "store-1", "type": "Feature", "geometry": {"coordinates": [13.4049999, 52.52], "type": "Point"}}`, name)
+	}
+
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Put(endpoint).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			mockSaveBody(&saved)(res)
+			name := regexp.MustCompile(`"name":"([^"]*)"`).FindStringSubmatch(saved)[1]
+			saved = current(name)
+			return mockBody(&saved)(res)
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get(endpoint).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(mockBody(&saved))
+
+	gock.New("https://api.mapbox.com").
+		Delete(endpoint).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusNoContent)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDatasetFeatureResourceConfig(username, datasetId, "Berlin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s/%s/store-1", username, datasetId)),
+					resource.TestCheckResourceAttr(resourceName, "geometry", `{"coordinates":[13.405,52.52],"type":"Point"}`),
+				),
+			},
+			// Coordinates within the tolerance do not produce a diff
+			{
+				Config:   testAccDatasetFeatureResourceConfig(username, datasetId, "Berlin"),
+				PlanOnly: true,
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("%s/%s/store-1", username, datasetId),
+				// Imported documents are formatted by the API.
+				ImportStateVerifyIgnore: []string{"geometry", "properties"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccDatasetFeatureResourceConfig(username, datasetId, "Berlin Mitte"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "properties", `{"name":"Berlin Mitte"}`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDatasetFeatureResource_invalidGeometry(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mapbox_dataset_feature" "test" {
+  username   = "test"
+  dataset_id = "cjz5g2fue0ed61cp6wy7tn1xe"
+  feature_id = "area-1"
+  geometry = jsonencode({
+    type        = "Polygon"
+    coordinates = [[[0, 0], [1, 0], [1, 1], [0, 1]]]
+  })
+}
+`,
+				ExpectError: regexp.MustCompile(`the ring is not closed`),
+			},
+		},
+	})
+}
+
+func TestGeoJSONEqual(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{`{"type": "Point", "coordinates": [13.405, 52.52]}`, `{"coordinates": [13.4049999, 52.52], "type": "Point"}`, true},
+		{`{"type": "Point", "coordinates": [13.405, 52.52]}`, `{"type": "Point", "coordinates": [13.406, 52.52]}`, false},
+		{`{"type": "Point", "coordinates": [13.405, 52.52]}`, `{"type": "Point", "coordinates": [13.405, 52.52, 34]}`, false},
+		{`{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1, 2]}]}`, `{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1.00000001, 2]}]}`, true},
+		{`{"elevation": 34, "coordinates": [1, 2]}`, `{"elevation": 34.0, "coordinates": [1.00000001, 2]}`, false},
+		{`{"elevation": 34, "name": "Berlin"}`, `{"name": "Berlin", "elevation": 34.0}`, true},
+		{`{"name": "Berlin"}`, `not json`, false},
+	}
+
+	for _, c := range cases {
+		if actual := geoJSONEqual(c.a, c.b); actual != c.expected {
+			t.Errorf("geoJSONEqual(%q, %q) = %t, expected %t", c.a, c.b, actual, c.expected)
+		}
+	}
+}
+
+func testAccDatasetFeatureResourceConfig(username, datasetId, name string) string {
+	return fmt.Sprintf(`
+resource "mapbox_dataset_feature" "test" {
+  username   = %[1]q
+  dataset_id = %[2]q
+  feature_id = "store-1"
+  geometry = jsonencode({
+    type        = "Point"
+    coordinates = [13.405, 52.52]
+  })
+  properties = jsonencode({
+    name = %[3]q
+  })
+}
+`, username, datasetId, name)
+}
@@ -0,0 +1,674 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatasetFeaturesResource{}
+var _ resource.ResourceWithModifyPlan = &DatasetFeaturesResource{}
+
+const defaultDatasetFeaturesParallelism = 8
+
+func NewDatasetFeaturesResource() resource.Resource {
+	return &DatasetFeaturesResource{}
+}
+
+// DatasetFeaturesResource defines the resource implementation.
+type DatasetFeaturesResource struct {
+	client *Client
+}
+
+// DatasetFeaturesResourceModel describes the resource data model.
+type DatasetFeaturesResourceModel struct {
+	DatasetId   types.String `tfsdk:"dataset_id"`
+	Features    types.Int64  `tfsdk:"features"`
+	FileHash    types.String `tfsdk:"file_hash"`
+	Id          types.String `tfsdk:"id"`
+	Modified    types.String `tfsdk:"modified"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	Path        types.String `tfsdk:"path"`
+	ToAdd       types.Int64  `tfsdk:"to_add"`
+	ToDelete    types.Int64  `tfsdk:"to_delete"`
+	ToUpdate    types.Int64  `tfsdk:"to_update"`
+	Username    types.String `tfsdk:"username"`
+}
+
+// datasetFeatureChanges are the feature IDs to add, update and delete to sync a dataset.
+type datasetFeatureChanges struct {
+	Added   []string
+	Updated []string
+	Deleted []string
+}
+
+func (c datasetFeatureChanges) empty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Deleted) == 0
+}
+
+func (r *DatasetFeaturesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_features"
+}
+
+func (r *DatasetFeaturesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Syncs the features of a dataset with a GeoJSON FeatureCollection file. The plan compares the file with the dataset by feature ID and shows how many features are added, updated and deleted in `to_add`, `to_update` and `to_delete`, and only those features are written. Features of the dataset that are not in the file are deleted, destroying the resource deletes all features but keeps the dataset. When some features cannot be written while creating the resource, it is not saved to state, so the next apply syncs the remaining features instead of replacing the resource and deleting all features first.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the dataset.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dataset_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the dataset.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of a GeoJSON FeatureCollection file. Every feature needs a unique `id`, which is its feature ID in the dataset. Geometries are validated like those of `mapbox_dataset_feature`.",
+				Required:            true,
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of features written or deleted at the same time. Defaults to `8`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultDatasetFeaturesParallelism),
+			},
+			"file_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of the file.",
+			},
+			"features": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of features in the dataset.",
+			},
+			"modified": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the dataset was last modified.",
+			},
+			"to_add": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of features of the file that the plan adds to the dataset. Reset to `0` when the dataset is read after syncing.",
+			},
+			"to_update": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of features of the dataset that the plan updates from the file. Reset to `0` when the dataset is read after syncing.",
+			},
+			"to_delete": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of features of the dataset that are not in the file and that the plan deletes. Reset to `0` when the dataset is read after syncing.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the dataset in the form `USERNAME/DATASET-ID`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DatasetFeaturesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan validates the file and compares it with the dataset, so the plan shows how many
+// features change in to_add, to_update and to_delete. The dataset is only written when they differ.
+func (r *DatasetFeaturesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data DatasetFeaturesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Path.IsUnknown() {
+		return
+	}
+
+	filePath := path.Root("path")
+	p := data.Path.ValueString()
+
+	hash, err := hashFile(p)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(filePath, "Unable to Read File", err.Error())
+		return
+	}
+
+	planned, invalid, err := readFeatureCollection(p)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(filePath, "Unable to Read File", fmt.Sprintf("%s: %s", p, err))
+		return
+	}
+
+	for i, featureErr := range invalid {
+		if i == maxFeatureErrors {
+			resp.Diagnostics.AddAttributeError(filePath, "Invalid Features", fmt.Sprintf("%s has %d more invalid features.", p, len(invalid)-maxFeatureErrors))
+			break
+		}
+
+		resp.Diagnostics.AddAttributeError(filePath, "Invalid Feature", fmt.Sprintf("%s, %s", p, featureErr))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	warnings := windingWarnings(planned)
+	for i, featureErr := range warnings {
+		if i == maxFeatureErrors {
+			resp.Diagnostics.AddAttributeWarning(filePath, "Polygon Winding", fmt.Sprintf("%s has %d more features that do not follow the right-hand rule.", p, len(warnings)-maxFeatureErrors))
+			break
+		}
+
+		resp.Diagnostics.AddAttributeWarning(filePath, "Polygon Winding", fmt.Sprintf("%s, %s does not follow the right-hand rule of RFC 7946: %s", p, featureErr.Location, featureErr.Message))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_hash"), hash)...)
+
+	// Without a known dataset the changes stay unknown and are counted during apply.
+	if data.Username.IsUnknown() || data.DatasetId.IsUnknown() || r.client == nil {
+		return
+	}
+
+	current, err := listDatasetFeatures(r.client, data.Username.ValueString(), data.DatasetId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset features, got error: %s", err))
+		return
+	}
+
+	changes := diffDatasetFeatures(current, planned)
+	data.setChanges(changes)
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("to_add"), data.ToAdd)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("to_update"), data.ToUpdate)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("to_delete"), data.ToDelete)...)
+
+	var state DatasetFeaturesResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if changes.empty() && state.FileHash.ValueString() == hash {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("features"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("modified"), types.StringUnknown())...)
+}
+
+func (r *DatasetFeaturesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatasetFeaturesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	data.Id = types.StringValue(data.Username.ValueString() + "/" + data.DatasetId.ValueString())
+
+	// A partly synced dataset is not saved, since the tainted resource would be replaced by
+	// deleting all features. The next apply syncs the remaining features instead.
+	diags := r.sync(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetFeaturesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatasetFeaturesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := getDataset(r.client, data.Username.ValueString(), data.DatasetId.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "dataset not found, removing features from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset, got error: %s", err))
+		return
+	}
+
+	data.Features = types.Int64Value(current.Features)
+	data.Modified = types.StringValue(current.Modified)
+
+	// The changes of the last sync are done, the next plan counts them again.
+	data.setChanges(datasetFeatureChanges{})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetFeaturesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DatasetFeaturesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := r.sync(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	// A failed sync leaves the values it did not get to unknown, which cannot be saved.
+	if diags.HasError() {
+		data.keepUnknown(state)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetFeaturesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatasetFeaturesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userName, datasetId := data.Username.ValueString(), data.DatasetId.ValueString()
+
+	current, err := listDatasetFeatures(r.client, userName, datasetId)
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset features, got error: %s", err))
+		return
+	}
+
+	_, err = runBatch("feature", sortedFeatureIds(current), data.parallelism(), func(id string) error {
+		return deleteDatasetFeature(r.client, userName, datasetId, id)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete dataset features, got error: %s", err))
+	}
+}
+
+// sync writes the features of the file that differ from the dataset and deletes the ones
+// that are not in the file. The computed attributes of data are set to what the dataset
+// holds afterwards, even when some of the requests failed.
+func (r *DatasetFeaturesResource) sync(ctx context.Context, data *DatasetFeaturesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	userName, datasetId := data.Username.ValueString(), data.DatasetId.ValueString()
+
+	hash, err := hashFile(data.Path.ValueString())
+	if err != nil {
+		diags.AddError("Unable to Read File", err.Error())
+		return diags
+	}
+
+	planned, invalid, err := readFeatureCollection(data.Path.ValueString())
+	if err == nil && len(invalid) > 0 {
+		err = invalid[0]
+	}
+	if err != nil {
+		diags.AddError("Unable to Read File", fmt.Sprintf("%s: %s", data.Path.ValueString(), err))
+		return diags
+	}
+
+	current, err := listDatasetFeatures(r.client, userName, datasetId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read dataset features, got error: %s", err))
+		return diags
+	}
+
+	changes := diffDatasetFeatures(current, planned)
+
+	if data.ToAdd.IsUnknown() {
+		data.setChanges(changes)
+	}
+
+	tflog.Info(ctx, "syncing dataset features", map[string]any{
+		"id":      data.Id.ValueString(),
+		"added":   len(changes.Added),
+		"updated": len(changes.Updated),
+		"deleted": len(changes.Deleted),
+	})
+
+	_, err = runBatch("feature", append(changes.Added, changes.Updated...), data.parallelism(), func(id string) error {
+		tflog.Debug(ctx, "writing dataset feature", map[string]any{"feature": id})
+		return putDatasetFeature(r.client, userName, datasetId, id, planned[id])
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to write dataset features, got error: %s", err))
+	}
+
+	_, err = runBatch("feature", changes.Deleted, data.parallelism(), func(id string) error {
+		tflog.Debug(ctx, "deleting dataset feature", map[string]any{"feature": id})
+		return deleteDatasetFeature(r.client, userName, datasetId, id)
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete dataset features, got error: %s", err))
+	}
+
+	dataset, err := getDataset(r.client, userName, datasetId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read dataset, got error: %s", err))
+		return diags
+	}
+
+	data.FileHash = types.StringValue(hash)
+	data.Features = types.Int64Value(dataset.Features)
+	data.Modified = types.StringValue(dataset.Modified)
+
+	return diags
+}
+
+// setChanges sets to_add, to_update and to_delete to the number of changed features.
+func (data *DatasetFeaturesResourceModel) setChanges(changes datasetFeatureChanges) {
+	data.ToAdd = types.Int64Value(int64(len(changes.Added)))
+	data.ToUpdate = types.Int64Value(int64(len(changes.Updated)))
+	data.ToDelete = types.Int64Value(int64(len(changes.Deleted)))
+}
+
+// keepUnknown replaces the computed values that are still unknown with those of state.
+func (data *DatasetFeaturesResourceModel) keepUnknown(state DatasetFeaturesResourceModel) {
+	if data.Features.IsUnknown() {
+		data.Features = state.Features
+	}
+	if data.FileHash.IsUnknown() {
+		data.FileHash = state.FileHash
+	}
+	if data.Modified.IsUnknown() {
+		data.Modified = state.Modified
+	}
+	if data.ToAdd.IsUnknown() {
+		data.ToAdd = state.ToAdd
+	}
+	if data.ToUpdate.IsUnknown() {
+		data.ToUpdate = state.ToUpdate
+	}
+	if data.ToDelete.IsUnknown() {
+		data.ToDelete = state.ToDelete
+	}
+}
+
+func (data DatasetFeaturesResourceModel) parallelism() int {
+	if data.Parallelism.IsNull() || data.Parallelism.IsUnknown() || data.Parallelism.ValueInt64() < 1 {
+		return defaultDatasetFeaturesParallelism
+	}
+
+	return int(data.Parallelism.ValueInt64())
+}
+
+// readFeatureCollection reads the features of a FeatureCollection file keyed by their ID.
+// Features without an ID, with a duplicate ID or with an invalid geometry are returned as
+// errors, the error is only set when the file as a whole cannot be read.
+func readFeatureCollection(p string) (map[string]json.RawMessage, []featureError, error) {
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var collection struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+
+	if err := json.Unmarshal(content, &collection); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if collection.Type != "FeatureCollection" {
+		return nil, nil, fmt.Errorf("expected a FeatureCollection, got type %q", collection.Type)
+	}
+
+	features := make(map[string]json.RawMessage, len(collection.Features))
+	positions := make(map[string]int, len(collection.Features))
+
+	var invalid []featureError
+
+	for i, feature := range collection.Features {
+		location := fmt.Sprintf("feature %d", i+1)
+
+		id, err := datasetFeatureId(feature)
+		if err == nil {
+			err = validateFeature(feature)
+		}
+		if err == nil && positions[id] > 0 {
+			err = fmt.Errorf("the ID %q is already used by feature %d", id, positions[id])
+		}
+		if err != nil {
+			invalid = append(invalid, featureError{location, err.Error()})
+			continue
+		}
+
+		features[id] = feature
+		positions[id] = i + 1
+	}
+
+	return features, invalid, nil
+}
+
+// datasetFeatureId returns the ID of a feature, numbers are turned into their decimal form.
+func datasetFeatureId(feature json.RawMessage) (string, error) {
+	var member struct {
+		Id any `json:"id"`
+	}
+
+	if err := json.Unmarshal(feature, &member); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch id := member.Id.(type) {
+	case string:
+		if id != "" {
+			return id, nil
+		}
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64), nil
+	}
+
+	return "", fmt.Errorf("the feature has no ID")
+}
+
+// windingWarnings returns the features whose polygons do not follow the right-hand rule, like
+// mapbox_dataset_feature warns about its geometry.
+func windingWarnings(features map[string]json.RawMessage) []featureError {
+	var warnings []featureError
+
+	for _, id := range sortedFeatureIds(features) {
+		var member struct {
+			Geometry json.RawMessage `json:"geometry"`
+		}
+
+		if err := json.Unmarshal(features[id], &member); err != nil {
+			continue
+		}
+
+		if err := validateWinding(member.Geometry); err != nil {
+			warnings = append(warnings, featureError{fmt.Sprintf("feature %q", id), err.Error()})
+		}
+	}
+
+	return warnings
+}
+
+// diffDatasetFeatures compares the features of a dataset with the planned ones by ID. The
+// geometries and properties are compared like those of mapbox_dataset_feature.
+func diffDatasetFeatures(current, planned map[string]json.RawMessage) datasetFeatureChanges {
+	var changes datasetFeatureChanges
+
+	for _, id := range sortedFeatureIds(planned) {
+		feature, ok := current[id]
+
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, id)
+		case !datasetFeaturesEqual(feature, planned[id]):
+			changes.Updated = append(changes.Updated, id)
+		}
+	}
+
+	for _, id := range sortedFeatureIds(current) {
+		if _, ok := planned[id]; !ok {
+			changes.Deleted = append(changes.Deleted, id)
+		}
+	}
+
+	return changes
+}
+
+func datasetFeaturesEqual(a, b json.RawMessage) bool {
+	featureA, errA := decodeDatasetFeature(a)
+	featureB, errB := decodeDatasetFeature(b)
+
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return geoJSONEqual(string(featureA.Geometry), string(featureB.Geometry)) &&
+		geoJSONEqual(string(featureA.Properties), string(featureB.Properties))
+}
+
+// decodeDatasetFeature decodes the geometry and properties of a feature, with missing
+// properties as an empty object. The ID is left to the caller since files may use numbers.
+func decodeDatasetFeature(content json.RawMessage) (datasetFeature, error) {
+	var members struct {
+		Geometry   json.RawMessage `json:"geometry"`
+		Properties json.RawMessage `json:"properties"`
+	}
+
+	if err := json.Unmarshal(content, &members); err != nil {
+		return datasetFeature{}, err
+	}
+
+	if len(members.Properties) == 0 || string(members.Properties) == "null" {
+		members.Properties = json.RawMessage("{}")
+	}
+
+	return datasetFeature{Geometry: members.Geometry, Properties: members.Properties}, nil
+}
+
+func sortedFeatureIds(features map[string]json.RawMessage) []string {
+	ids := make([]string, 0, len(features))
+	for id := range features {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// listDatasetFeatures returns all features of a dataset keyed by their ID.
+func listDatasetFeatures(client *Client, username, datasetId string) (map[string]json.RawMessage, error) {
+	features := map[string]json.RawMessage{}
+
+	err := client.ListPages(datasetEndpoint(username, datasetId)+"/features?limit=100", func(body []byte) error {
+		var page struct {
+			Features []json.RawMessage `json:"features"`
+		}
+
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+
+		for _, feature := range page.Features {
+			id, err := datasetFeatureId(feature)
+			if err != nil {
+				return err
+			}
+
+			features[id] = feature
+		}
+
+		return nil
+	})
+
+	return features, err
+}
+
+// putDatasetFeature writes a feature of the file to the dataset under id.
+func putDatasetFeature(client *Client, username, datasetId, id string, content json.RawMessage) error {
+	feature, err := decodeDatasetFeature(content)
+	if err != nil {
+		return err
+	}
+
+	feature.Id, feature.Type = id, "Feature"
+
+	bytedata, err := json.Marshal(feature)
+	if err != nil {
+		return fmt.Errorf("encode feature: %w", err)
+	}
+
+	resp, err := client.Put(datasetFeatureEndpoint(username, datasetId, id), bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func deleteDatasetFeature(client *Client, username, datasetId, id string) error {
+	resp, err := client.Delete(datasetFeatureEndpoint(username, datasetId, id))
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
@@ -0,0 +1,338 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccDatasetFeaturesResource_basic(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("dataset features are only tested against mocks")
+	}
+
+	resourceName := "mapbox_dataset_features.test"
+	username := "test"
+	datasetId := "cjz5g2fue0ed61cp6wy7tn1xe"
+	endpoint := fmt.Sprintf("datasets/v1/%s/%s", username, datasetId)
+
+	filePath := filepath.Join(t.TempDir(), "stores.geojson")
+	writeFile := func(content string) {
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(`{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "id": "berlin", "geometry": {"type": "Point", "coordinates": [13.405, 52.52]}, "properties": {"name": "Berlin"}},
+  {"type": "Feature", "id": 2, "geometry": {"type": "Point", "coordinates": [2.3522, 48.8566]}, "properties": {"name": "Paris"}}
+]}`)
+
+	// The dataset starts with a feature that is not in the file, and the API stores
+	// coordinates with fewer digits.
+	features := map[string]string{
+		"rome": `{"type": "Feature", "id": "rome", "geometry": {"type": "Point", "coordinates": [12.4964, 41.9028]}, "properties": {}}`,
+	}
+	var (
+		mu            sync.Mutex
+		puts, deletes int
+		failRead      bool
+	)
+
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Put(endpoint+"/features/").
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			var saved string
+			mockSaveBody(&saved)(res)
+
+			var feature datasetFeature
+			if err := json.Unmarshal([]byte(saved), &feature); err != nil {
+				t.Errorf("unexpected feature %q: %s", saved, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			features[feature.Id] = saved
+			puts++
+
+			return res
+		})
+
+	gock.New("https://api.mapbox.com").
+		Delete(endpoint+"/features/").
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusNoContent).
+		Map(func(res *http.Response) *http.Response {
+			mu.Lock()
+			defer mu.Unlock()
+			delete(features, filepath.Base(res.Request.URL.Path))
+			deletes++
+			return res
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get(endpoint+"/features").
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			mu.Lock()
+			defer mu.Unlock()
+			collection := `{"type": "FeatureCollection", "features": [`
+			for i, id := range sortedFeatureIds(toRawFeatures(features)) {
+				if i > 0 {
+					collection += ","
+				}
+				collection += features[id]
+			}
+			collection += "]}"
+			return mockBody(&collection)(res)
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get(endpoint).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			mu.Lock()
+			defer mu.Unlock()
+			if failRead && puts > 0 {
+				res.StatusCode = http.StatusInternalServerError
+				return res
+			}
+			current := fmt.Sprintf(`{"id": %q, "owner": %q, "features": %d, "modified": "2024-01-0%dT00:00:00.000Z"}`, datasetId, username, len(features), puts+deletes)
+			return mockBody(&current)(res)
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDatasetFeaturesResourceConfig(username, datasetId, filePath),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("to_add"), knownvalue.Int64Exact(2)),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("to_update"), knownvalue.Int64Exact(0)),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("to_delete"), knownvalue.Int64Exact(1)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", username+"/"+datasetId),
+					resource.TestCheckResourceAttr(resourceName, "features", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "file_hash"),
+					func(*terraform.State) error {
+						if puts != 2 || deletes != 1 {
+							return fmt.Errorf("expected 2 puts and 1 delete, got %d and %d", puts, deletes)
+						}
+						return nil
+					},
+				),
+			},
+			// Features outside of the file are deleted again
+			{
+				PreConfig: func() {
+					features["rome"] = `{"type": "Feature", "id": "rome", "geometry": {"type": "Point", "coordinates": [12.4964, 41.9028]}, "properties": {}}`
+				},
+				Config: testAccDatasetFeaturesResourceConfig(username, datasetId, filePath),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("to_add"), knownvalue.Int64Exact(0)),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("to_delete"), knownvalue.Int64Exact(1)),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "features", "2"),
+			},
+			// Only changed features are written
+			{
+				PreConfig: func() {
+					puts = 0
+					writeFile(`{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "id": "berlin", "geometry": {"type": "Point", "coordinates": [13.4049999, 52.52]}, "properties": {"name": "Berlin"}},
+  {"type": "Feature", "id": 2, "geometry": {"type": "Point", "coordinates": [2.3522, 48.8566]}, "properties": {"name": "Paris, France"}}
+]}`)
+				},
+				Config: testAccDatasetFeaturesResourceConfig(username, datasetId, filePath),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("to_update"), knownvalue.Int64Exact(1)),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if puts != 1 {
+						return fmt.Errorf("expected 1 put, got %d", puts)
+					}
+					return nil
+				},
+			},
+			// A dataset that cannot be read after the features are written fails the update
+			{
+				PreConfig: func() {
+					puts = 0
+					failRead = true
+					writeFile(`{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "id": "berlin", "geometry": {"type": "Point", "coordinates": [13.4049999, 52.52]}, "properties": {"name": "Berlin"}},
+  {"type": "Feature", "id": 2, "geometry": {"type": "Point", "coordinates": [2.3522, 48.8566]}, "properties": {"name": "Paris, France"}},
+  {"type": "Feature", "id": "madrid", "geometry": {"type": "Point", "coordinates": [-3.7038, 40.4168]}, "properties": {"name": "Madrid"}}
+]}`)
+				},
+				Config:      testAccDatasetFeaturesResourceConfig(username, datasetId, filePath),
+				ExpectError: regexp.MustCompile(`Unable to read dataset, got error`),
+			},
+			// The next apply finds the written feature and only reads the dataset
+			{
+				PreConfig: func() {
+					puts = 0
+					failRead = false
+				},
+				Config: testAccDatasetFeaturesResourceConfig(username, datasetId, filePath),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("to_add"), knownvalue.Int64Exact(0)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "features", "3"),
+					func(*terraform.State) error {
+						if puts != 0 {
+							return fmt.Errorf("expected no puts, got %d", puts)
+						}
+						return nil
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDatasetFeaturesResource_invalidFeatures(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "stores.geojson")
+	if err := os.WriteFile(filePath, []byte(`{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [13.405, 52.52]}, "properties": {}}
+]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDatasetFeaturesResourceConfig("test", "cjz5g2fue0ed61cp6wy7tn1xe", filePath),
+				ExpectError: regexp.MustCompile(`feature 1: the feature has no ID`),
+			},
+		},
+	})
+}
+
+func TestReadFeatureCollection(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "stores.geojson")
+	if err := os.WriteFile(p, []byte(`{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "id": "berlin", "geometry": {"type": "Point", "coordinates": [13.405, 52.52]}, "properties": {}},
+  {"type": "Feature", "id": 2, "geometry": {"type": "Point", "coordinates": [2.3522, 48.8566]}},
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}},
+  {"type": "Feature", "id": "berlin", "geometry": {"type": "Point", "coordinates": [13.4, 52.5]}},
+  {"type": "Feature", "id": "area", "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 1], [1, 0], [0, 0]]]}}
+]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	features, invalid, err := readFeatureCollection(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if ids := sortedFeatureIds(features); !reflect.DeepEqual(ids, []string{"2", "area", "berlin"}) {
+		t.Errorf("got feature IDs %v, expected [2 area berlin]", ids)
+	}
+
+	expected := []string{
+		"feature 3: the feature has no ID",
+		`feature 4: the ID "berlin" is already used by feature 1`,
+	}
+
+	if len(invalid) != len(expected) {
+		t.Fatalf("got invalid features %v, expected %v", invalid, expected)
+	}
+
+	for i, featureErr := range invalid {
+		if featureErr.Error() != expected[i] {
+			t.Errorf("invalid feature %d is %q, expected %q", i, featureErr, expected[i])
+		}
+	}
+
+	warnings := windingWarnings(features)
+	if len(warnings) != 1 || warnings[0].Error() != `feature "area": the exterior ring of the Polygon is clockwise, expected counterclockwise` {
+		t.Errorf("got winding warnings %v, expected only the clockwise area", warnings)
+	}
+}
+
+func TestDiffDatasetFeatures(t *testing.T) {
+	current := toRawFeatures(map[string]string{
+		"same":     `{"type": "Feature", "id": "same", "geometry": {"type": "Point", "coordinates": [13.4049999, 52.52]}, "properties": {}}`,
+		"moved":    `{"type": "Feature", "id": "moved", "geometry": {"type": "Point", "coordinates": [13.405, 52.52]}, "properties": {}}`,
+		"renamed":  `{"type": "Feature", "id": "renamed", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {"name": "a"}}`,
+		"obsolete": `{"type": "Feature", "id": "obsolete", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {}}`,
+	})
+
+	planned := toRawFeatures(map[string]string{
+		"same":    `{"type": "Feature", "id": "same", "geometry": {"type": "Point", "coordinates": [13.405, 52.52]}}`,
+		"moved":   `{"type": "Feature", "id": "moved", "geometry": {"type": "Point", "coordinates": [13.406, 52.52]}, "properties": {}}`,
+		"renamed": `{"type": "Feature", "id": "renamed", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {"name": "b"}}`,
+		"new":     `{"type": "Feature", "id": "new", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {}}`,
+	})
+
+	expected := datasetFeatureChanges{
+		Added:   []string{"new"},
+		Updated: []string{"moved", "renamed"},
+		Deleted: []string{"obsolete"},
+	}
+
+	if changes := diffDatasetFeatures(current, planned); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("diffDatasetFeatures() = %+v, expected %+v", changes, expected)
+	}
+}
+
+func toRawFeatures(features map[string]string) map[string]json.RawMessage {
+	raw := make(map[string]json.RawMessage, len(features))
+	for id, feature := range features {
+		raw[id] = json.RawMessage(feature)
+	}
+
+	return raw
+}
+
+func testAccDatasetFeaturesResourceConfig(username, datasetId, filePath string) string {
+	return fmt.Sprintf(`
+resource "mapbox_dataset_features" "test" {
+  username    = %[1]q
+  dataset_id  = %[2]q
+  path        = %[3]q
+  parallelism = 2
+}
+`, username, datasetId, filePath)
+}
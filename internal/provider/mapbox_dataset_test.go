@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccDatasetResource_basic(t *testing.T) {
+	resourceName := "mapbox_dataset.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+
+	if os.Getenv("MOCK") != "" {
+		datasetsEndpoint := fmt.Sprintf("datasets/v1/%s", username)
+		id := "cjz5g2fue0ed61cp6wy7tn1xe"
+
+		datasetDocument := func(description, modified string) string {
+			return fmt.Sprintf(`{
+  "owner": %[1]q,
+  "id": %[2]q,
+  "created": "2024-01-01T00:00:00.000Z",
+  "modified": %[4]q,
+  "bounds": [-10, -10, 10, 10],
+  "features": 3,
+  "size": 420,
+  "name": "tf-acc-test-dataset",
+  "description": %[3]q
+}`, username, id, description, modified)
+		}
+
+		current := datasetDocument("Points of interest", "2024-01-01T00:00:00.000Z")
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Post(datasetsEndpoint).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(mockBody(&current))
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("%s/%s", datasetsEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(mockBody(&current))
+
+		gock.New("https://api.mapbox.com").
+			Patch(fmt.Sprintf("%s/%s", datasetsEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			BodyString(`"description":"Points of interest in Berlin"`).
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				current = datasetDocument("Points of interest in Berlin", "2024-01-02T00:00:00.000Z")
+				return mockBody(&current)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Delete(fmt.Sprintf("%s/%s", datasetsEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusNoContent)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDatasetResourceConfig(username, "Points of interest"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "username", username),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-test-dataset"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Points of interest"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "created"),
+					resource.TestCheckResourceAttrSet(resourceName, "modified"),
+					resource.TestCheckResourceAttrSet(resourceName, "features"),
+					resource.TestCheckResourceAttrSet(resourceName, "size"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccUsernameImportStateId(resourceName),
+			},
+			// Update and Read testing
+			{
+				Config: testAccDatasetResourceConfig(username, "Points of interest in Berlin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Points of interest in Berlin"),
+					resource.TestCheckResourceAttrSet(resourceName, "modified"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccDatasetResourceConfig(username, description string) string {
+	return fmt.Sprintf(`
+resource "mapbox_dataset" "test" {
+  username    = %[1]q
+  name        = "tf-acc-test-dataset"
+  description = %[2]q
+}
+`, username, description)
+}
@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FontResource{}
+var _ resource.ResourceWithModifyPlan = &FontResource{}
+
+func NewFontResource() resource.Resource {
+	return &FontResource{}
+}
+
+// FontResource defines the resource implementation.
+type FontResource struct {
+	client *Client
+}
+
+// FontResourceModel describes the resource data model.
+type FontResourceModel struct {
+	ContentHash types.String `tfsdk:"content_hash"`
+	FamilyName  types.String `tfsdk:"family_name"`
+	Fonts       types.List   `tfsdk:"fonts"`
+	Id          types.String `tfsdk:"id"`
+	Path        types.String `tfsdk:"path"`
+	StyleName   types.String `tfsdk:"style_name"`
+	Username    types.String `tfsdk:"username"`
+}
+
+// fontMetadata is the metadata the Fonts API returns for an uploaded font file.
+type fontMetadata struct {
+	FamilyName string   `json:"family_name"`
+	StyleName  string   `json:"style_name"`
+	Owner      string   `json:"owner"`
+	Filename   string   `json:"filename"`
+	Fonts      []string `json:"fonts"`
+}
+
+func (r *FontResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_font"
+}
+
+func (r *FontResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a TTF or OTF font file through the Fonts API. The uploaded fonts can be referenced by name from the `text-font` property of style layers.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account the font is uploaded to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of the TTF or OTF file to upload. The font is uploaded again when the content of the file changes.",
+				Required:            true,
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of the uploaded file.",
+			},
+			"family_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The font family name, e.g. `Open Sans`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"style_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The font style name, e.g. `Bold`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fonts": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the fonts the file provides, as used in `text-font`.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the font in the form `USERNAME/FONT-NAME`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FontResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan hashes the font file during plan, a changed file replaces the font.
+func (r *FontResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data FontResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Path.IsUnknown() {
+		return
+	}
+
+	content, err := os.ReadFile(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to Read Font", err.Error())
+		return
+	}
+
+	hash := contentHash(content)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), hash)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state FontResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ContentHash.ValueString() != hash {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_hash"))
+	}
+}
+
+func (r *FontResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FontResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	file, err := os.Open(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Font", err.Error())
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	httpResp, err := r.client.PostBody(fmt.Sprintf("fonts/v1/%s", data.Username.ValueString()), file, "application/octet-stream")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload font %s, got error: %s", filepath.Base(data.Path.ValueString()), err))
+		return
+	}
+
+	var metadata fontMetadata
+	if err := decodeJSON(httpResp, &metadata); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload font, got error: %s", err))
+		return
+	}
+
+	if len(metadata.Fonts) == 0 {
+		resp.Diagnostics.AddError("Client Error", "The Fonts API did not return any font names for the uploaded file")
+		return
+	}
+
+	data.Id = types.StringValue(data.Username.ValueString() + "/" + metadata.Fonts[0])
+	resp.Diagnostics.Append(data.setMetadata(ctx, metadata)...)
+
+	tflog.Trace(ctx, "uploaded a font", map[string]any{"id": data.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FontResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FontResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var fonts []string
+	resp.Diagnostics.Append(data.Fonts.ElementsAs(ctx, &fonts, false)...)
+
+	if resp.Diagnostics.HasError() || len(fonts) == 0 {
+		return
+	}
+
+	httpResp, err := r.client.Get(fontEndpoint(data.Username.ValueString(), fonts[0]) + "/metadata")
+	if isNotFound(err) {
+		tflog.Warn(ctx, "font not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read font, got error: %s", err))
+		return
+	}
+
+	var metadata fontMetadata
+	if err := decodeJSON(httpResp, &metadata); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read font, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.setMetadata(ctx, metadata)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only records a new path, a changed file replaces the font.
+func (r *FontResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FontResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FontResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FontResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var fonts []string
+	resp.Diagnostics.Append(data.Fonts.ElementsAs(ctx, &fonts, false)...)
+
+	for _, font := range fonts {
+		httpResp, err := r.client.Delete(fontEndpoint(data.Username.ValueString(), font))
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete font %q, got error: %s", font, err))
+			continue
+		}
+
+		_ = httpResp.Body.Close()
+	}
+}
+
+func (data *FontResourceModel) setMetadata(ctx context.Context, metadata fontMetadata) diag.Diagnostics {
+	data.FamilyName = types.StringValue(metadata.FamilyName)
+	data.StyleName = types.StringValue(metadata.StyleName)
+
+	fonts, diags := types.ListValueFrom(ctx, types.StringType, metadata.Fonts)
+	data.Fonts = fonts
+
+	return diags
+}
+
+func fontEndpoint(userName, font string) string {
+	return fmt.Sprintf("fonts/v1/%s/%s", userName, url.PathEscape(font))
+}
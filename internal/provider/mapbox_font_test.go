@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccFontResource_basic(t *testing.T) {
+	resourceName := "mapbox_font.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	fontPath := os.Getenv("MAPBOX_FONT_PATH")
+
+	if os.Getenv("MOCK") != "" {
+		fontPath = filepath.Join(t.TempDir(), "Inter-Bold.ttf")
+		if err := os.WriteFile(fontPath, []byte("\x00\x01\x00\x00 not really a font"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		metadata := `{
+  "family_name": "Inter",
+  "style_name": "Bold",
+  "owner": "test-token",
+  "filename": "Inter-Bold.ttf",
+  "fonts": ["Inter Bold"]
+}`
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Post(fmt.Sprintf("fonts/v1/%s", username)).
+			MatchHeader("Content-Type", "application/octet-stream").
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusCreated).
+			BodyString(metadata)
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("fonts/v1/%s/Inter%%20Bold/metadata", username)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			BodyString(metadata)
+
+		gock.New("https://api.mapbox.com").
+			Delete(fmt.Sprintf("fonts/v1/%s/Inter%%20Bold", username)).
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusNoContent)
+	} else if fontPath == "" {
+		t.Skip("MAPBOX_FONT_PATH must be set to a TTF or OTF file to upload")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccFontResourceConfig(username, fontPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s/Inter Bold", username)),
+					resource.TestCheckResourceAttr(resourceName, "family_name", "Inter"),
+					resource.TestCheckResourceAttr(resourceName, "style_name", "Bold"),
+					resource.TestCheckResourceAttr(resourceName, "fonts.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "fonts.0", "Inter Bold"),
+					resource.TestCheckResourceAttrSet(resourceName, "content_hash"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccFontResourceConfig(username, fontPath string) string {
+	return fmt.Sprintf(`
+resource "mapbox_font" "test" {
+  username = %[1]q
+  path     = %[2]q
+}
+`, username, fontPath)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FontsDataSource{}
+
+func NewFontsDataSource() datasource.DataSource {
+	return &FontsDataSource{}
+}
+
+// FontsDataSource defines the data source implementation.
+type FontsDataSource struct {
+	client *Client
+}
+
+// FontsDataSourceModel describes the data source data model.
+type FontsDataSourceModel struct {
+	Fonts    types.List   `tfsdk:"fonts"`
+	Id       types.String `tfsdk:"id"`
+	Username types.String `tfsdk:"username"`
+}
+
+func (d *FontsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fonts"
+}
+
+func (d *FontsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the custom fonts uploaded to an account.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account to list the fonts of.",
+				Required:            true,
+			},
+			"fonts": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the fonts, sorted alphabetically.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username the fonts were listed for.",
+			},
+		},
+	}
+}
+
+func (d *FontsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *FontsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FontsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := d.client.Get(fmt.Sprintf("fonts/v1/%s", data.Username.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list fonts, got error: %s", err))
+		return
+	}
+
+	fonts := []string{}
+	if err := decodeJSON(httpResp, &fonts); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list fonts, got error: %s", err))
+		return
+	}
+	sort.Strings(fonts)
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, fonts)
+	resp.Diagnostics.Append(diags...)
+
+	data.Fonts = list
+	data.Id = data.Username
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccFontsDataSource_basic(t *testing.T) {
+	dataSourceName := "data.mapbox_fonts.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+
+	if os.Getenv("MOCK") != "" {
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("fonts/v1/%s", username)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			BodyString(`["Inter Regular", "Inter Bold"]`)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "mapbox_fonts" "test" {
+  username = %q
+}
+`, username),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", username),
+					resource.TestCheckResourceAttr(dataSourceName, "fonts.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "fonts.0", "Inter Bold"),
+				),
+			},
+		},
+	})
+}
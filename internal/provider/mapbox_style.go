@@ -0,0 +1,497 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StyleResource{}
+var _ resource.ResourceWithImportState = &StyleResource{}
+var _ resource.ResourceWithValidateConfig = &StyleResource{}
+var _ resource.ResourceWithModifyPlan = &StyleResource{}
+
+func NewStyleResource() resource.Resource {
+	return &StyleResource{}
+}
+
+// StyleResource defines the resource implementation.
+type StyleResource struct {
+	client *Client
+}
+
+// StyleResourceModel describes the resource data model.
+type StyleResourceModel struct {
+	Created        types.String `tfsdk:"created"`
+	Id             types.String `tfsdk:"id"`
+	Modified       types.String `tfsdk:"modified"`
+	Owner          types.String `tfsdk:"owner"`
+	Publish        types.Bool   `tfsdk:"publish"`
+	PublishedStyle StyleJSON    `tfsdk:"published_style"`
+	Style          StyleJSON    `tfsdk:"style"`
+	Url            types.String `tfsdk:"url"`
+	Username       types.String `tfsdk:"username"`
+}
+
+// styleMetadata is the part of a style document that is managed by Mapbox.
+type styleMetadata struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Owner    string `json:"owner"`
+	Created  string `json:"created"`
+	Modified string `json:"modified"`
+}
+
+func (r *StyleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_style"
+}
+
+func (r *StyleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Mapbox GL style through the Styles API. The style document is validated against the style specification during plan, without calling the API.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the style.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"style": schema.StringAttribute{
+				CustomType:          StyleJSONType{},
+				MarkdownDescription: "The style document as JSON, following the Mapbox GL style specification. Server managed fields such as `created` and `modified`, key order and number formatting are ignored when comparing documents.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					styleJSONPlanModifier{},
+				},
+			},
+			"publish": schema.BoolAttribute{
+				MarkdownDescription: "Whether changes to `style` are published. When `false`, updates are written to the draft of the style and the published version is left as is until `publish` is set to `true` again. A new style is always created published. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"published_style": schema.StringAttribute{
+				CustomType:          StyleJSONType{},
+				Computed:            true,
+				MarkdownDescription: "The published style document. It differs from `style` while draft changes are waiting to be published.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Style identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username of the style owner.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the style was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the style was last modified.",
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The `mapbox://styles/` URL of the style.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *StyleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks the style document against the style specification, so a bad
+// document fails the plan instead of the API request midway through an apply.
+func (r *StyleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var style StyleJSON
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("style"), &style)...)
+
+	if resp.Diagnostics.HasError() || style.IsNull() || style.IsUnknown() {
+		return
+	}
+
+	doc, err := normalizeStyleDocument(style.ValueString())
+	if err != nil {
+		// Reported by the attribute type.
+		return
+	}
+
+	for _, specErr := range validateStyleDocument(doc) {
+		resp.Diagnostics.AddAttributeError(path.Root("style"), "Invalid Style Document", specErr.Error())
+	}
+}
+
+// ModifyPlan plans the published document next to the draft, so the plan shows what a
+// reviewer publishes separately from what is written to the draft.
+func (r *StyleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state StyleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || plan.Style.IsUnknown() || plan.Publish.IsUnknown() {
+		return
+	}
+
+	published := state.PublishedStyle
+	if published.IsNull() {
+		published = state.Style
+	}
+
+	if plan.Publish.ValueBool() {
+		prior, err := normalizeStyleDocument(published.ValueString())
+		if err != nil {
+			return
+		}
+
+		planned, err := normalizeStyleDocument(plan.Style.ValueString())
+		if err != nil {
+			return
+		}
+
+		if changes := diffStyleDocuments(prior, planned); len(changes) > 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("published_style"),
+				"Published Style Changes",
+				"The published style will be updated:\n\n"+strings.Join(changes, "\n"),
+			)
+			published = plan.Style
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("published_style"), published)...)
+}
+
+func (r *StyleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StyleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	body, err := styleRequestBody(data.Style.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("style"), "Parsing Error", fmt.Sprintf("Unable to parse style, got error: %s", err))
+		return
+	}
+
+	createResp, err := r.client.Post(fmt.Sprintf("styles/v1/%s", data.Username.ValueString()), body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create style, got error: %s", err))
+		return
+	}
+
+	var style styleMetadata
+	if err := decodeJSON(createResp, &style); err != nil {
+		resp.Diagnostics.AddError("Unmarshall Error", fmt.Sprintf("Unable to create style, got error: %s", err))
+		return
+	}
+
+	data.setMetadata(style)
+	data.PublishedStyle = data.Style
+
+	tflog.Trace(ctx, "created a style", map[string]any{"id": style.Id})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StyleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint := styleEndpoint(data.Username.ValueString(), data.Id.ValueString())
+
+	style, published, err := readStyle(r.client, endpoint)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "style not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read style, got error: %s", err))
+		return
+	}
+
+	// The configured document is kept by semantic equality unless the style was changed
+	// outside of Terraform.
+	data.Style = published
+	data.PublishedStyle = published
+
+	if data.Publish.IsNull() {
+		data.Publish = types.BoolValue(true)
+	}
+
+	if !data.Publish.ValueBool() {
+		draftStyle, draft, err := readStyle(r.client, endpoint+"/draft")
+		switch {
+		case isNotFound(err):
+			// There are no draft changes.
+		case err != nil:
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read style draft, got error: %s", err))
+			return
+		default:
+			style = draftStyle
+			data.Style = draft
+		}
+	}
+
+	data.setMetadata(style)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state StyleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := styleRequestBody(data.Style.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("style"), "Parsing Error", fmt.Sprintf("Unable to parse style, got error: %s", err))
+		return
+	}
+
+	endpoint := styleEndpoint(data.Username.ValueString(), data.Id.ValueString())
+
+	// Drafts are published by writing them to the draft first, so a style that was edited
+	// as a draft is promoted as a whole rather than overwritten.
+	wasDraft := !state.Publish.IsNull() && !state.Publish.ValueBool()
+	if !data.Publish.ValueBool() || wasDraft {
+		endpoint += "/draft"
+	}
+
+	updateResp, err := r.client.Patch(endpoint, body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update style, got error: %s", err))
+		return
+	}
+
+	var style styleMetadata
+	if err := decodeJSON(updateResp, &style); err != nil {
+		resp.Diagnostics.AddError("Unmarshall Error", fmt.Sprintf("Unable to update style, got error: %s", err))
+		return
+	}
+
+	data.setMetadata(style)
+
+	if data.Publish.ValueBool() && wasDraft {
+		publishResp, err := r.client.Post(styleEndpoint(data.Username.ValueString(), data.Id.ValueString())+"/publish", nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to publish style, got error: %s", err))
+			return
+		}
+		_ = publishResp.Body.Close()
+	}
+
+	if data.Publish.ValueBool() {
+		data.PublishedStyle = data.Style
+	} else {
+		data.PublishedStyle = state.PublishedStyle
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StyleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := r.client.Delete(styleEndpoint(data.Username.ValueString(), data.Id.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete style, got error: %s", err))
+		return
+	}
+	if deleteResp != nil {
+		defer func() {
+			_ = deleteResp.Body.Close()
+		}()
+	}
+}
+
+func (r *StyleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	userName, id, err := styleId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), userName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (data *StyleResourceModel) setMetadata(style styleMetadata) {
+	data.Id = types.StringValue(style.Id)
+	data.Owner = types.StringValue(style.Owner)
+	data.Created = types.StringValue(style.Created)
+	data.Modified = types.StringValue(style.Modified)
+	data.Url = types.StringValue(fmt.Sprintf("mapbox://styles/%s/%s", style.Owner, style.Id))
+}
+
+// styleRequestBody strips the server managed fields from a configured style document.
+func styleRequestBody(raw string) (*bytes.Buffer, error) {
+	doc, err := normalizeStyleDocument(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	bytedata, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewBuffer(bytedata), nil
+}
+
+func styleEndpoint(userName, id string) string {
+	return fmt.Sprintf("styles/v1/%s/%s", userName, id)
+}
+
+func styleId(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%q), expected USERNAME/STYLE-ID", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// readStyle fetches the style or its draft at endpoint and returns its metadata along with the
+// normalized document.
+func readStyle(client *Client, endpoint string) (styleMetadata, StyleJSON, error) {
+	var style styleMetadata
+
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return style, StyleJSON{}, err
+	}
+
+	var document json.RawMessage
+	if err := decodeJSON(resp, &document); err != nil {
+		return style, StyleJSON{}, err
+	}
+
+	if err := json.Unmarshal(document, &style); err != nil {
+		return style, StyleJSON{}, err
+	}
+
+	normalized, err := normalizeStyleDocument(string(document))
+	if err != nil {
+		return style, StyleJSON{}, err
+	}
+
+	styleJSON, err := json.Marshal(normalized)
+	if err != nil {
+		return style, StyleJSON{}, err
+	}
+
+	return style, NewStyleJSONValue(string(styleJSON)), nil
+}
+
+// getStyleDocument fetches a style and returns its document without the server managed fields.
+func getStyleDocument(client *Client, userName, id string) (map[string]any, error) {
+	resp, err := client.Get(styleEndpoint(userName, id))
+	if err != nil {
+		return nil, err
+	}
+
+	var document json.RawMessage
+	if err := decodeJSON(resp, &document); err != nil {
+		return nil, err
+	}
+
+	return normalizeStyleDocument(string(document))
+}
+
+// patchStyleDocument replaces the document of a style.
+func patchStyleDocument(client *Client, userName, id string, doc map[string]any) error {
+	bytedata, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Patch(styleEndpoint(userName, id), bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
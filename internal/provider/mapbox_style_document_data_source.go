@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &StyleDocumentDataSource{}
+
+func NewStyleDocumentDataSource() datasource.DataSource {
+	return &StyleDocumentDataSource{}
+}
+
+// StyleDocumentDataSource defines the data source implementation.
+type StyleDocumentDataSource struct {
+	client *Client
+}
+
+// StyleDocumentDataSourceModel describes the data source data model.
+type StyleDocumentDataSourceModel struct {
+	Draft       types.Bool   `tfsdk:"draft"`
+	Id          types.String `tfsdk:"id"`
+	Json        types.String `tfsdk:"json"`
+	Name        types.String `tfsdk:"name"`
+	StripGlyphs types.Bool   `tfsdk:"strip_glyphs"`
+	StripSprite types.Bool   `tfsdk:"strip_sprite"`
+	StyleId     types.String `tfsdk:"style_id"`
+	Username    types.String `tfsdk:"username"`
+}
+
+func (d *StyleDocumentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_style_document"
+}
+
+func (d *StyleDocumentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the document of a style without the server managed fields, in the same form `mapbox_style` compares documents in. The output is stable, so it can be written to a file with `local_file` and committed.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the style.",
+				Required:            true,
+			},
+			"style_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the style.",
+				Required:            true,
+			},
+			"draft": schema.BoolAttribute{
+				MarkdownDescription: "Fetch the draft of the style instead of the published version.",
+				Optional:            true,
+			},
+			"strip_sprite": schema.BoolAttribute{
+				MarkdownDescription: "Remove the `sprite` URL from the document.",
+				Optional:            true,
+			},
+			"strip_glyphs": schema.BoolAttribute{
+				MarkdownDescription: "Remove the `glyphs` URL from the document.",
+				Optional:            true,
+			},
+			"json": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The style document as indented JSON with sorted keys.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the style.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the style in the form `USERNAME/STYLE-ID`.",
+			},
+		},
+	}
+}
+
+func (d *StyleDocumentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *StyleDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data StyleDocumentDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint := styleEndpoint(data.Username.ValueString(), data.StyleId.ValueString())
+	if data.Draft.ValueBool() {
+		endpoint += "/draft"
+	}
+
+	httpResp, err := d.client.Get(endpoint)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read style, got error: %s", err))
+		return
+	}
+
+	var document json.RawMessage
+	if err := decodeJSON(httpResp, &document); err != nil {
+		resp.Diagnostics.AddError("Unmarshall Error", fmt.Sprintf("Unable to read style, got error: %s", err))
+		return
+	}
+
+	doc, err := normalizeStyleDocument(string(document))
+	if err != nil {
+		resp.Diagnostics.AddError("Unmarshall Error", fmt.Sprintf("Unable to read style, got error: %s", err))
+		return
+	}
+
+	if data.StripSprite.ValueBool() {
+		delete(doc, "sprite")
+	}
+	if data.StripGlyphs.ValueBool() {
+		delete(doc, "glyphs")
+	}
+
+	out, err := marshalStyleJSON(doc)
+	if err != nil {
+		resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Unable to encode style, got error: %s", err))
+		return
+	}
+
+	name, _ := doc["name"].(string)
+
+	data.Id = types.StringValue(data.Username.ValueString() + "/" + data.StyleId.ValueString())
+	data.Json = types.StringValue(out + "\n")
+	data.Name = types.StringValue(name)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccStyleDocumentDataSource_basic(t *testing.T) {
+	dataSourceName := "data.mapbox_style_document.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	styleId := os.Getenv("MAPBOX_STYLE_ID")
+
+	if os.Getenv("MOCK") != "" {
+		styleId = "cjz5g2fue0ed61cp6wy7tn1xe"
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("styles/v1/%s/%s", username, styleId)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			BodyString(`{"version": 8, "name": "Basemap", "id": "cjz5g2fue0ed61cp6wy7tn1xe", "owner": "test-token", "modified": "2024-01-02T00:00:00.000Z", "sprite": "mapbox://sprites/test-token/cjz5g2fue0ed61cp6wy7tn1xe", "glyphs": "mapbox://fonts/test-token/{fontstack}/{range}.pbf", "sources": {}, "layers": [{"type": "background", "id": "background"}]}`)
+	} else if styleId == "" {
+		t.Skip("MAPBOX_STYLE_ID must be set to a style to export")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "mapbox_style_document" "test" {
+  username     = %q
+  style_id     = %q
+  strip_sprite = true
+}
+`, username, styleId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "name", "Basemap"),
+					resource.TestCheckResourceAttr(dataSourceName, "json", `{
+  "glyphs": "mapbox://fonts/test-token/{fontstack}/{range}.pbf",
+  "layers": [
+    {
+      "id": "background",
+      "type": "background"
+    }
+  ],
+  "name": "Basemap",
+  "sources": {},
+  "version": 8
+}
+`),
+				),
+			},
+		},
+	})
+}
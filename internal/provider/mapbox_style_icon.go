@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StyleIconResource{}
+var _ resource.ResourceWithImportState = &StyleIconResource{}
+var _ resource.ResourceWithValidateConfig = &StyleIconResource{}
+var _ resource.ResourceWithModifyPlan = &StyleIconResource{}
+
+func NewStyleIconResource() resource.Resource {
+	return &StyleIconResource{}
+}
+
+// StyleIconResource defines the resource implementation.
+type StyleIconResource struct {
+	client *Client
+}
+
+// StyleIconResourceModel describes the resource data model.
+type StyleIconResourceModel struct {
+	ContentHash types.String `tfsdk:"content_hash"`
+	IconName    types.String `tfsdk:"icon_name"`
+	Id          types.String `tfsdk:"id"`
+	StyleId     types.String `tfsdk:"style_id"`
+	Svg         types.String `tfsdk:"svg"`
+	SvgPath     types.String `tfsdk:"svg_path"`
+	Username    types.String `tfsdk:"username"`
+}
+
+func (r *StyleIconResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_style_icon"
+}
+
+func (r *StyleIconResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a custom icon in the sprite of a style.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the style.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"style_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the style whose sprite holds the icon.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"icon_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the icon, as referenced by `icon-image` in the style.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"svg_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the SVG file to upload. Exactly one of `svg_path` and `svg` must be set.",
+				Optional:            true,
+			},
+			"svg": schema.StringAttribute{
+				MarkdownDescription: "The SVG document to upload. Exactly one of `svg_path` and `svg` must be set.",
+				Optional:            true,
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of the uploaded SVG, a changed file is uploaded again.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the icon in the form `USERNAME/STYLE-ID/ICON-NAME`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *StyleIconResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *StyleIconResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data StyleIconResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Svg.IsNull() == data.SvgPath.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("svg_path"),
+			"Invalid Attribute Combination",
+			"Exactly one of svg_path and svg must be set.",
+		)
+	}
+}
+
+// ModifyPlan hashes the SVG during plan, so changes to the file show up as an update.
+func (r *StyleIconResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data StyleIconResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Svg.IsUnknown() || data.SvgPath.IsUnknown() {
+		return
+	}
+
+	content, err := data.content()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("svg_path"), "Unable to Read Icon", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), contentHash(content))...)
+}
+
+func (r *StyleIconResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StyleIconResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	if err := r.upload(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload icon, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(strings.Join([]string{data.Username.ValueString(), data.StyleId.ValueString(), data.IconName.ValueString()}, "/"))
+
+	tflog.Trace(ctx, "uploaded a style icon", map[string]any{"id": data.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleIconResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StyleIconResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	icons, err := getSpriteIcons(r.client, data.Username.ValueString(), data.StyleId.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "style not found, removing icon from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sprite, got error: %s", err))
+		return
+	}
+
+	if _, ok := icons[data.IconName.ValueString()]; !ok {
+		tflog.Warn(ctx, "icon not found in sprite, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleIconResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StyleIconResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.upload(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload icon, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleIconResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StyleIconResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteSpriteIcon(r.client, data.Username.ValueString(), data.StyleId.ValueString(), data.IconName.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete icon, got error: %s", err))
+	}
+}
+
+func (r *StyleIconResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("unexpected format of ID (%q), expected USERNAME/STYLE-ID/ICON-NAME", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("style_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("icon_name"), parts[2])...)
+}
+
+func (r *StyleIconResource) upload(data *StyleIconResourceModel) error {
+	content, err := data.content()
+	if err != nil {
+		return err
+	}
+
+	if err := putSpriteIcon(r.client, data.Username.ValueString(), data.StyleId.ValueString(), data.IconName.ValueString(), content); err != nil {
+		return err
+	}
+
+	data.ContentHash = types.StringValue(contentHash(content))
+	return nil
+}
+
+func (data StyleIconResourceModel) content() ([]byte, error) {
+	if !data.SvgPath.IsNull() {
+		return os.ReadFile(data.SvgPath.ValueString())
+	}
+
+	return []byte(data.Svg.ValueString()), nil
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func spriteIconEndpoint(userName, styleId, iconName string) string {
+	return fmt.Sprintf("styles/v1/%s/%s/sprite/%s", userName, styleId, url.PathEscape(iconName))
+}
+
+func putSpriteIcon(client *Client, userName, styleId, iconName string, svg []byte) error {
+	resp, err := client.PutBody(spriteIconEndpoint(userName, styleId, iconName), bytes.NewReader(svg), "image/svg+xml")
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func deleteSpriteIcon(client *Client, userName, styleId, iconName string) error {
+	resp, err := client.Delete(spriteIconEndpoint(userName, styleId, iconName))
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// getSpriteIcons returns the sprite index of a style, keyed by icon name.
+func getSpriteIcons(client *Client, userName, styleId string) (map[string]any, error) {
+	resp, err := client.Get(fmt.Sprintf("styles/v1/%s/%s/sprite.json", userName, styleId))
+	if err != nil {
+		return nil, err
+	}
+
+	icons := map[string]any{}
+	if err := decodeJSON(resp, &icons); err != nil {
+		return nil, err
+	}
+
+	return icons, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const testAccStyleIconSvg = `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16"><circle cx="8" cy="8" r="6"/></svg>`
+
+func TestAccStyleIconResource_basic(t *testing.T) {
+	resourceName := "mapbox_style_icon.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	styleId := os.Getenv("MAPBOX_STYLE_ID")
+
+	if os.Getenv("MOCK") != "" {
+		styleId = "cjz5g2fue0ed61cp6wy7tn1xe"
+		iconEndpoint := fmt.Sprintf("styles/v1/%s/%s/sprite/marker", username, styleId)
+
+		sprite := `{}`
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("styles/v1/%s/%s/sprite.json", username, styleId)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(mockBody(&sprite))
+
+		gock.New("https://api.mapbox.com").
+			Put(iconEndpoint).
+			MatchHeader("Content-Type", "image/svg+xml").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				sprite = `{"marker": {"width": 16, "height": 16, "x": 0, "y": 0, "pixelRatio": 1}}`
+				return mockBody(&sprite)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Delete(iconEndpoint).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusNoContent).
+			Map(func(res *http.Response) *http.Response {
+				sprite = `{}`
+				return res
+			})
+	} else if styleId == "" {
+		t.Skip("MAPBOX_STYLE_ID must be set to a style the icon can be added to")
+	}
+
+	svgPath := filepath.Join(t.TempDir(), "marker.svg")
+	if err := os.WriteFile(svgPath, []byte(testAccStyleIconSvg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccStyleIconResourceConfig(username, styleId, fmt.Sprintf("svg = %q", testAccStyleIconSvg)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s/%s/marker", username, styleId)),
+					resource.TestCheckResourceAttr(resourceName, "content_hash", contentHash([]byte(testAccStyleIconSvg))),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"svg", "content_hash"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccStyleIconResourceConfig(username, styleId, fmt.Sprintf("svg_path = %q", svgPath)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "svg_path", svgPath),
+					resource.TestCheckResourceAttr(resourceName, "content_hash", contentHash([]byte(testAccStyleIconSvg))),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccStyleIconResourceConfig(username, styleId, content string) string {
+	return fmt.Sprintf(`
+resource "mapbox_style_icon" "test" {
+  username  = %[1]q
+  style_id  = %[2]q
+  icon_name = "marker"
+  %[3]s
+}
+`, username, styleId, content)
+}
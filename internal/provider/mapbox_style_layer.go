@@ -0,0 +1,508 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StyleLayerResource{}
+var _ resource.ResourceWithImportState = &StyleLayerResource{}
+var _ resource.ResourceWithValidateConfig = &StyleLayerResource{}
+
+func NewStyleLayerResource() resource.Resource {
+	return &StyleLayerResource{}
+}
+
+// StyleLayerResource defines the resource implementation.
+type StyleLayerResource struct {
+	client *Client
+}
+
+// StyleLayerResourceModel describes the resource data model.
+type StyleLayerResourceModel struct {
+	Before      types.String  `tfsdk:"before"`
+	Filter      types.Dynamic `tfsdk:"filter"`
+	Id          types.String  `tfsdk:"id"`
+	LayerId     types.String  `tfsdk:"layer_id"`
+	Layout      types.Dynamic `tfsdk:"layout"`
+	Maxzoom     types.Float64 `tfsdk:"maxzoom"`
+	Minzoom     types.Float64 `tfsdk:"minzoom"`
+	Paint       types.Dynamic `tfsdk:"paint"`
+	Slot        types.String  `tfsdk:"slot"`
+	Source      types.String  `tfsdk:"source"`
+	SourceLayer types.String  `tfsdk:"source_layer"`
+	StyleId     types.String  `tfsdk:"style_id"`
+	Type        types.String  `tfsdk:"type"`
+	Username    types.String  `tfsdk:"username"`
+}
+
+func (r *StyleLayerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_style_layer"
+}
+
+func (r *StyleLayerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single layer of a style, e.g. of a style that is owned by another team or designed in Studio. " +
+			"Do not use it on styles whose document is managed by `mapbox_style`, the two would overwrite each other.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the style.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"style_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the style the layer belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"layer_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the layer, unique within the style.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The layer type, e.g. `fill`, `line` or `symbol`.",
+				Required:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The name of the style source the layer draws from. Required for every type but `background`, `sky` and `slot`.",
+				Optional:            true,
+			},
+			"source_layer": schema.StringAttribute{
+				MarkdownDescription: "The layer of a vector source to use.",
+				Optional:            true,
+			},
+			"minzoom": schema.Float64Attribute{
+				MarkdownDescription: "The minimum zoom level for the layer.",
+				Optional:            true,
+			},
+			"maxzoom": schema.Float64Attribute{
+				MarkdownDescription: "The maximum zoom level for the layer.",
+				Optional:            true,
+			},
+			"filter": schema.DynamicAttribute{
+				MarkdownDescription: "An expression specifying conditions on source features, e.g. `[\"==\", [\"get\", \"class\"], \"street\"]`.",
+				Optional:            true,
+			},
+			"paint": schema.DynamicAttribute{
+				MarkdownDescription: "An object of paint properties, values may be literals or expressions.",
+				Optional:            true,
+			},
+			"layout": schema.DynamicAttribute{
+				MarkdownDescription: "An object of layout properties, values may be literals or expressions.",
+				Optional:            true,
+			},
+			"slot": schema.StringAttribute{
+				MarkdownDescription: "The slot of an imported style the layer is placed in.",
+				Optional:            true,
+			},
+			"before": schema.StringAttribute{
+				MarkdownDescription: "The ID of an existing layer to place this layer below. A new layer is placed on top of the style when not set, and an existing layer keeps its position.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the layer in the form `USERNAME/STYLE-ID/LAYER-ID`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *StyleLayerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *StyleLayerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data StyleLayerResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	layer, err := data.layer()
+	if err != nil {
+		// Unknown values are validated once they are known.
+		return
+	}
+
+	// The sources of the style are not known without calling the API, they are checked on apply.
+	for _, message := range validateStyleLayer(layer, nil) {
+		resp.Diagnostics.AddError("Invalid Style Layer", fmt.Sprintf("layer %q: %s", data.LayerId.ValueString(), message))
+	}
+}
+
+func (r *StyleLayerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StyleLayerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	resp.Diagnostics.Append(r.writeLayer(ctx, data, true)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(strings.Join([]string{data.Username.ValueString(), data.StyleId.ValueString(), data.LayerId.ValueString()}, "/"))
+
+	tflog.Trace(ctx, "created a style layer", map[string]any{"id": data.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleLayerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StyleLayerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	doc, err := getStyleDocument(r.client, data.Username.ValueString(), data.StyleId.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "style not found, removing layer from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read style, got error: %s", err))
+		return
+	}
+
+	layers, _ := doc["layers"].([]any)
+
+	index := styleLayerIndex(layers, data.LayerId.ValueString())
+	if index < 0 {
+		tflog.Warn(ctx, "style layer not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	layer, _ := layers[index].(map[string]any)
+	data.setLayer(layer)
+
+	// Only report a different position when the layer is no longer below the configured one.
+	if !data.Before.IsNull() {
+		beforeIndex := styleLayerIndex(layers, data.Before.ValueString())
+		if beforeIndex < index {
+			data.Before = types.StringNull()
+			if index+1 < len(layers) {
+				if next, ok := layers[index+1].(map[string]any); ok {
+					data.Before = types.StringValue(fmt.Sprint(next["id"]))
+				}
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleLayerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StyleLayerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.writeLayer(ctx, data, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleLayerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StyleLayerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userName, styleId := data.Username.ValueString(), data.StyleId.ValueString()
+
+	styleLocks.Lock(styleEndpoint(userName, styleId))
+	defer styleLocks.Unlock(styleEndpoint(userName, styleId))
+
+	doc, err := getStyleDocument(r.client, userName, styleId)
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read style, got error: %s", err))
+		return
+	}
+
+	layers, _ := doc["layers"].([]any)
+
+	index := styleLayerIndex(layers, data.LayerId.ValueString())
+	if index < 0 {
+		return
+	}
+
+	doc["layers"] = append(layers[:index:index], layers[index+1:]...)
+
+	if err := patchStyleDocument(r.client, userName, styleId, doc); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete style layer, got error: %s", err))
+	}
+}
+
+func (r *StyleLayerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("unexpected format of ID (%q), expected USERNAME/STYLE-ID/LAYER-ID", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("style_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("layer_id"), parts[2])...)
+}
+
+// writeLayer places the layer into the style document and saves it. The style is locked
+// for the whole read-modify-write, so layers of the same style can be applied in parallel.
+func (r *StyleLayerResource) writeLayer(ctx context.Context, data StyleLayerResourceModel, create bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	layer, err := data.layer()
+	if err != nil {
+		diags.AddError("Parsing Error", fmt.Sprintf("Unable to build style layer, got error: %s", err))
+		return diags
+	}
+
+	userName, styleId, layerId := data.Username.ValueString(), data.StyleId.ValueString(), data.LayerId.ValueString()
+
+	styleLocks.Lock(styleEndpoint(userName, styleId))
+	defer styleLocks.Unlock(styleEndpoint(userName, styleId))
+
+	doc, err := getStyleDocument(r.client, userName, styleId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read style, got error: %s", err))
+		return diags
+	}
+
+	layers, _ := doc["layers"].([]any)
+
+	if create && styleLayerIndex(layers, layerId) >= 0 {
+		diags.AddAttributeError(
+			path.Root("layer_id"),
+			"Style Layer Already Exists",
+			fmt.Sprintf("The style %s already has a layer %q, import it to bring it under management.", styleId, layerId),
+		)
+		return diags
+	}
+
+	layers, err = placeStyleLayer(layers, layer, data.Before.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("before"), "Invalid Layer Position", err.Error())
+		return diags
+	}
+
+	doc["layers"] = layers
+
+	// Other layers of the style may be invalid already, only report this layer.
+	for _, specErr := range validateStyleDocument(doc) {
+		if specErr.Layer == layerId {
+			diags.AddError("Invalid Style Layer", specErr.Error())
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	tflog.Debug(ctx, "patching style layer", map[string]any{"style": styleId, "layer": layerId})
+
+	if err := patchStyleDocument(r.client, userName, styleId, doc); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to write style layer, got error: %s", err))
+	}
+
+	return diags
+}
+
+// layer builds the style document representation of the layer.
+func (data StyleLayerResourceModel) layer() (map[string]any, error) {
+	if data.LayerId.IsUnknown() || data.Type.IsUnknown() {
+		return nil, fmt.Errorf("layer id and type are not known yet")
+	}
+
+	layer := map[string]any{
+		"id":   data.LayerId.ValueString(),
+		"type": data.Type.ValueString(),
+	}
+
+	strs := map[string]types.String{"source": data.Source, "source-layer": data.SourceLayer, "slot": data.Slot}
+	for key, value := range strs {
+		if value.IsUnknown() {
+			return nil, fmt.Errorf("%s is not known yet", key)
+		}
+
+		if !value.IsNull() {
+			layer[key] = value.ValueString()
+		}
+	}
+
+	zooms := map[string]types.Float64{"minzoom": data.Minzoom, "maxzoom": data.Maxzoom}
+	for key, value := range zooms {
+		if value.IsUnknown() {
+			return nil, fmt.Errorf("%s is not known yet", key)
+		}
+
+		if !value.IsNull() {
+			layer[key] = value.ValueFloat64()
+		}
+	}
+
+	dynamics := map[string]types.Dynamic{"filter": data.Filter, "paint": data.Paint, "layout": data.Layout}
+	for key, value := range dynamics {
+		if value.IsNull() || value.IsUnderlyingValueNull() {
+			continue
+		}
+
+		converted, err := dynamicToJSON(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		layer[key] = converted
+	}
+
+	return layer, nil
+}
+
+// setLayer updates the model from the style document representation of the layer, keeping
+// the prior dynamic values where they are equivalent to the ones returned by the API.
+func (data *StyleLayerResourceModel) setLayer(layer map[string]any) {
+	data.Type = types.StringValue(fmt.Sprint(layer["type"]))
+
+	strs := map[string]*types.String{"source": &data.Source, "source-layer": &data.SourceLayer, "slot": &data.Slot}
+	for key, target := range strs {
+		if value, ok := layer[key].(string); ok {
+			*target = types.StringValue(value)
+		} else {
+			*target = types.StringNull()
+		}
+	}
+
+	zooms := map[string]*types.Float64{"minzoom": &data.Minzoom, "maxzoom": &data.Maxzoom}
+	for key, target := range zooms {
+		if value, ok := layer[key].(float64); ok {
+			*target = types.Float64Value(value)
+		} else {
+			*target = types.Float64Null()
+		}
+	}
+
+	dynamics := map[string]*types.Dynamic{"filter": &data.Filter, "paint": &data.Paint, "layout": &data.Layout}
+	for key, target := range dynamics {
+		if prior, err := dynamicToJSON(*target); err == nil && reflect.DeepEqual(prior, layer[key]) {
+			continue
+		}
+
+		*target = jsonToDynamic(layer[key])
+	}
+}
+
+func styleLayerIndex(layers []any, id string) int {
+	for i, item := range layers {
+		if layer, ok := item.(map[string]any); ok && layer["id"] == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// placeStyleLayer places the layer below the before layer. Without before, an existing layer
+// with the same id is replaced in place and a new layer is added on top.
+func placeStyleLayer(layers []any, layer map[string]any, before string) ([]any, error) {
+	if before == "" {
+		placed := append(make([]any, 0, len(layers)+1), layers...)
+
+		id, _ := layer["id"].(string)
+		if index := styleLayerIndex(placed, id); index >= 0 {
+			placed[index] = layer
+			return placed, nil
+		}
+
+		return append(placed, layer), nil
+	}
+
+	placed := make([]any, 0, len(layers)+1)
+	for _, item := range layers {
+		if existing, ok := item.(map[string]any); ok && existing["id"] == layer["id"] {
+			continue
+		}
+
+		placed = append(placed, item)
+	}
+
+	index := styleLayerIndex(placed, before)
+	if index < 0 {
+		return nil, fmt.Errorf("the style has no layer %q to place the layer below", before)
+	}
+
+	return append(placed[:index], append([]any{layer}, placed[index:]...)...), nil
+}
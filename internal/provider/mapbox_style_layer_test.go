@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccStyleLayerResource_basic(t *testing.T) {
+	resourceName := "mapbox_style_layer.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	styleId := os.Getenv("MAPBOX_STYLE_ID")
+
+	if os.Getenv("MOCK") != "" {
+		styleId = "cjz5g2fue0ed61cp6wy7tn1xe"
+		styleEndpoint := fmt.Sprintf("styles/v1/%s/%s", username, styleId)
+
+		current := `{
+  "version": 8,
+  "name": "owned by another team",
+  "id": "cjz5g2fue0ed61cp6wy7tn1xe",
+  "owner": "test-token",
+  "sources": {"composite": {"type": "vector", "url": "mapbox://mapbox.mapbox-streets-v8"}},
+  "layers": [
+    {"id": "background", "type": "background"},
+    {"id": "labels", "type": "symbol", "source": "composite", "source-layer": "place_label"}
+  ]
+}`
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(styleEndpoint).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(mockBody(&current))
+
+		gock.New("https://api.mapbox.com").
+			Patch(styleEndpoint).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(mockSaveBody(&current))
+	} else if styleId == "" {
+		t.Skip("MAPBOX_STYLE_ID must be set to a style the layer can be added to")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccStyleLayerResourceConfig(username, styleId, "#0000ff", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s/%s/roads", username, styleId)),
+					resource.TestCheckResourceAttr(resourceName, "type", "line"),
+					resource.TestCheckResourceAttr(resourceName, "before", "labels"),
+					resource.TestCheckResourceAttr(resourceName, "minzoom", "5"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"before"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccStyleLayerResourceConfig(username, styleId, "#ff0000", 4),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "line"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestPlaceStyleLayer(t *testing.T) {
+	layers := []any{
+		map[string]any{"id": "background"},
+		map[string]any{"id": "roads", "type": "line"},
+		map[string]any{"id": "labels"},
+	}
+
+	layerIds := func(layers []any) []string {
+		var ids []string
+		for _, layer := range layers {
+			ids = append(ids, layer.(map[string]any)["id"].(string))
+		}
+		return ids
+	}
+
+	placed, err := placeStyleLayer(layers, map[string]any{"id": "water"}, "roads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := layerIds(placed); !reflect.DeepEqual(ids, []string{"background", "water", "roads", "labels"}) {
+		t.Errorf("unexpected order: %v", ids)
+	}
+
+	// Updating a layer without before keeps its position.
+	placed, err = placeStyleLayer(layers, map[string]any{"id": "roads", "type": "fill"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := layerIds(placed); !reflect.DeepEqual(ids, []string{"background", "roads", "labels"}) {
+		t.Errorf("unexpected order: %v", ids)
+	}
+	if layerType := placed[1].(map[string]any)["type"]; layerType != "fill" {
+		t.Errorf("expected the updated layer, got type %v", layerType)
+	}
+	if layerType := layers[1].(map[string]any)["type"]; layerType != "line" {
+		t.Errorf("expected the original layers to be unchanged, got type %v", layerType)
+	}
+
+	placed, err = placeStyleLayer(layers, map[string]any{"id": "water"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := layerIds(placed); !reflect.DeepEqual(ids, []string{"background", "roads", "labels", "water"}) {
+		t.Errorf("unexpected order: %v", ids)
+	}
+
+	if _, err := placeStyleLayer(layers, map[string]any{"id": "water"}, "missing"); err == nil {
+		t.Errorf("expected an error for a missing before layer")
+	}
+}
+
+func testAccStyleLayerResourceConfig(username, styleId, color string, width int) string {
+	return fmt.Sprintf(`
+resource "mapbox_style_layer" "test" {
+  username     = %[1]q
+  style_id     = %[2]q
+  layer_id     = "roads"
+  type         = "line"
+  source       = "composite"
+  source_layer = "road"
+  minzoom      = 5
+  before       = "labels"
+  filter       = ["==", ["get", "class"], "street"]
+
+  paint = {
+    "line-color" = %[3]q
+    "line-width" = ["interpolate", ["linear"], ["zoom"], 5, 1, 18, %[4]d]
+  }
+
+  layout = {
+    "line-cap" = "round"
+  }
+}
+`, username, styleId, color, width)
+}
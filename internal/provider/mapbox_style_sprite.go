@@ -0,0 +1,431 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StyleSpriteResource{}
+var _ resource.ResourceWithModifyPlan = &StyleSpriteResource{}
+
+func NewStyleSpriteResource() resource.Resource {
+	return &StyleSpriteResource{}
+}
+
+// StyleSpriteResource defines the resource implementation.
+type StyleSpriteResource struct {
+	client *Client
+}
+
+// StyleSpriteResourceModel describes the resource data model.
+type StyleSpriteResourceModel struct {
+	Glob        types.String `tfsdk:"glob"`
+	Icons       types.Map    `tfsdk:"icons"`
+	Id          types.String `tfsdk:"id"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	StyleId     types.String `tfsdk:"style_id"`
+	Username    types.String `tfsdk:"username"`
+}
+
+func (r *StyleSpriteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_style_sprite"
+}
+
+func (r *StyleSpriteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Syncs a directory of SVG icons into the sprite of a style. Only icons whose files were added, changed or removed are uploaded or deleted. Icons that are not matched by the glob, e.g. those managed by `mapbox_style_icon`, are left alone.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the style.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"style_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the style whose sprite holds the icons.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"glob": schema.StringAttribute{
+				MarkdownDescription: "A glob matching the SVG files to upload, e.g. `${path.module}/icons/*.svg`. Each file is uploaded as an icon named after the file without its extension.",
+				Required:            true,
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of icons uploaded or deleted at the same time. Defaults to `8`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultSpriteParallelism),
+			},
+			"icons": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The SHA-256 hashes of the uploaded icons, keyed by icon name.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the sprite in the form `USERNAME/STYLE-ID`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *StyleSpriteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan hashes the matched files, so the plan shows which icons change.
+func (r *StyleSpriteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data StyleSpriteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Glob.IsUnknown() {
+		return
+	}
+
+	files, err := globSpriteIcons(data.Glob.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("glob"), "Unable to Read Icons", err.Error())
+		return
+	}
+
+	planned := make(map[string]string, len(files))
+	for name, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("glob"), "Unable to Read Icons", err.Error())
+			return
+		}
+
+		planned[name] = contentHash(content)
+	}
+
+	prior := map[string]string{}
+	if !req.State.Raw.IsNull() {
+		var state StyleSpriteResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(state.Icons.ElementsAs(ctx, &prior, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if changes := diffSpriteIcons(prior, planned); len(changes) > 0 && !req.State.Raw.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("icons"),
+			"Sprite Changes",
+			"The sprite icons will be updated:\n\n"+strings.Join(changes, "\n"),
+		)
+	}
+
+	icons, diags := types.MapValueFrom(ctx, types.StringType, planned)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("icons"), icons)...)
+}
+
+func (r *StyleSpriteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StyleSpriteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	data.Id = types.StringValue(data.Username.ValueString() + "/" + data.StyleId.ValueString())
+
+	resp.Diagnostics.Append(r.sync(ctx, &data, map[string]string{})...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleSpriteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StyleSpriteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sprite, err := getSpriteIcons(r.client, data.Username.ValueString(), data.StyleId.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "style not found, removing sprite from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sprite, got error: %s", err))
+		return
+	}
+
+	icons := map[string]string{}
+	resp.Diagnostics.Append(data.Icons.ElementsAs(ctx, &icons, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Icons deleted outside of Terraform are dropped, so the next apply uploads them again.
+	for name := range icons {
+		if _, ok := sprite[name]; !ok {
+			delete(icons, name)
+		}
+	}
+
+	resp.Diagnostics.Append(data.setIcons(ctx, icons)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleSpriteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state StyleSpriteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := map[string]string{}
+	resp.Diagnostics.Append(state.Icons.ElementsAs(ctx, &prior, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &data, prior)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StyleSpriteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StyleSpriteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := map[string]string{}
+	resp.Diagnostics.Append(data.Icons.ElementsAs(ctx, &prior, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	for name := range prior {
+		names = append(names, name)
+	}
+
+	userName, styleId := data.Username.ValueString(), data.StyleId.ValueString()
+	_, err := runBatch("icon", names, data.parallelism(), func(name string) error {
+		err := deleteSpriteIcon(r.client, userName, styleId, name)
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete icons, got error: %s", err))
+	}
+}
+
+// sync uploads the planned icons that differ from prior and deletes the ones no longer
+// matched by the glob. The icons of data are set to what the sprite holds afterwards, even
+// when some of the requests failed.
+func (r *StyleSpriteResource) sync(ctx context.Context, data *StyleSpriteResourceModel, prior map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	files, err := globSpriteIcons(data.Glob.ValueString())
+	if err != nil {
+		diags.AddError("Unable to Read Icons", err.Error())
+		return append(diags, data.setIcons(ctx, prior)...)
+	}
+
+	icons := make(map[string]string, len(prior))
+	for name, hash := range prior {
+		icons[name] = hash
+	}
+
+	hashes := map[string]string{}
+	var uploads, deletes []string
+	for name, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			diags.AddError("Unable to Read Icons", err.Error())
+			return append(diags, data.setIcons(ctx, prior)...)
+		}
+
+		hashes[name] = contentHash(content)
+		if prior[name] != hashes[name] {
+			uploads = append(uploads, name)
+		}
+	}
+	for name := range prior {
+		if _, ok := files[name]; !ok {
+			deletes = append(deletes, name)
+		}
+	}
+
+	userName, styleId := data.Username.ValueString(), data.StyleId.ValueString()
+
+	uploaded, err := runBatch("icon", uploads, data.parallelism(), func(name string) error {
+		content, err := os.ReadFile(files[name])
+		if err != nil {
+			return err
+		}
+
+		tflog.Debug(ctx, "uploading sprite icon", map[string]any{"icon": name})
+		return putSpriteIcon(r.client, userName, styleId, name, content)
+	})
+	for _, name := range uploaded {
+		icons[name] = hashes[name]
+	}
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to upload icons, got error: %s", err))
+	}
+
+	deleted, err := runBatch("icon", deletes, data.parallelism(), func(name string) error {
+		tflog.Debug(ctx, "deleting sprite icon", map[string]any{"icon": name})
+
+		err := deleteSpriteIcon(r.client, userName, styleId, name)
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	for _, name := range deleted {
+		delete(icons, name)
+	}
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete icons, got error: %s", err))
+	}
+
+	return append(diags, data.setIcons(ctx, icons)...)
+}
+
+func (data *StyleSpriteResourceModel) setIcons(ctx context.Context, icons map[string]string) diag.Diagnostics {
+	value, diags := types.MapValueFrom(ctx, types.StringType, icons)
+	data.Icons = value
+
+	return diags
+}
+
+func (data StyleSpriteResourceModel) parallelism() int {
+	if data.Parallelism.IsNull() || data.Parallelism.IsUnknown() || data.Parallelism.ValueInt64() < 1 {
+		return defaultSpriteParallelism
+	}
+
+	return int(data.Parallelism.ValueInt64())
+}
+
+const defaultSpriteParallelism = 8
+
+// globSpriteIcons returns the files matched by pattern keyed by icon name.
+func globSpriteIcons(pattern string) (map[string]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(matches))
+	for _, match := range matches {
+		name := strings.TrimSuffix(filepath.Base(match), filepath.Ext(match))
+		if other, ok := files[name]; ok {
+			return nil, fmt.Errorf("%s and %s would both be uploaded as icon %q", other, match, name)
+		}
+
+		files[name] = match
+	}
+
+	return files, nil
+}
+
+// diffSpriteIcons summarizes the icon changes between two sets of icon hashes.
+func diffSpriteIcons(prior, planned map[string]string) []string {
+	names := map[string]bool{}
+	for name := range prior {
+		names[name] = true
+	}
+	for name := range planned {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, name := range sorted {
+		before, hadBefore := prior[name]
+		after, hasAfter := planned[name]
+
+		switch {
+		case !hadBefore:
+			changes = append(changes, fmt.Sprintf("  + icon %q", name))
+		case !hasAfter:
+			changes = append(changes, fmt.Sprintf("  - icon %q", name))
+		case before != after:
+			changes = append(changes, fmt.Sprintf("  ~ icon %q", name))
+		}
+	}
+
+	return changes
+}
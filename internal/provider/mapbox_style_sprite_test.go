@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccStyleSpriteResource_basic(t *testing.T) {
+	resourceName := "mapbox_style_sprite.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	styleId := os.Getenv("MAPBOX_STYLE_ID")
+
+	if os.Getenv("MOCK") != "" {
+		styleId = "cjz5g2fue0ed61cp6wy7tn1xe"
+		spriteEndpoint := fmt.Sprintf("styles/v1/%s/%s/sprite", username, styleId)
+
+		var mu sync.Mutex
+		icons := map[string]any{}
+		sprite := func(res *http.Response) *http.Response {
+			mu.Lock()
+			defer mu.Unlock()
+
+			body, _ := json.Marshal(icons)
+			current := string(body)
+			return mockBody(&current)(res)
+		}
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(spriteEndpoint+".json").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(sprite)
+
+		gock.New("https://api.mapbox.com").
+			Put(spriteEndpoint+"/.+").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				mu.Lock()
+				icons[path.Base(res.Request.URL.Path)] = map[string]any{"width": 16, "height": 16}
+				mu.Unlock()
+				return sprite(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Delete(spriteEndpoint+"/.+").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusNoContent).
+			Map(func(res *http.Response) *http.Response {
+				mu.Lock()
+				delete(icons, path.Base(res.Request.URL.Path))
+				mu.Unlock()
+				return res
+			})
+	} else if styleId == "" {
+		t.Skip("MAPBOX_STYLE_ID must be set to a style the icons can be added to")
+	}
+
+	dir := t.TempDir()
+	writeIcon := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeIcon("marker.svg", testAccStyleIconSvg)
+	writeIcon("park.svg", `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16"><rect width="12" height="12"/></svg>`)
+
+	changed := `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16"><circle cx="8" cy="8" r="4"/></svg>`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccStyleSpriteResourceConfig(username, styleId, dir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s/%s", username, styleId)),
+					resource.TestCheckResourceAttr(resourceName, "icons.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "icons.marker", contentHash([]byte(testAccStyleIconSvg))),
+				),
+			},
+			// Update and Read testing
+			{
+				PreConfig: func() {
+					writeIcon("marker.svg", changed)
+					if err := os.Remove(filepath.Join(dir, "park.svg")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccStyleSpriteResourceConfig(username, styleId, dir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "icons.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "icons.marker", contentHash([]byte(changed))),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestDiffSpriteIcons(t *testing.T) {
+	prior := map[string]string{"marker": "a", "park": "b", "zoo": "c"}
+	planned := map[string]string{"marker": "a", "park": "x", "airport": "d"}
+
+	want := []string{
+		`  + icon "airport"`,
+		`  ~ icon "park"`,
+		`  - icon "zoo"`,
+	}
+
+	if got := diffSpriteIcons(prior, planned); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected changes:\n%#v", got)
+	}
+}
+
+func testAccStyleSpriteResourceConfig(username, styleId, dir string) string {
+	return fmt.Sprintf(`
+resource "mapbox_style_sprite" "test" {
+  username    = %[1]q
+  style_id    = %[2]q
+  glob        = %[3]q
+  parallelism = 2
+}
+`, username, styleId, filepath.Join(dir, "*.svg"))
+}
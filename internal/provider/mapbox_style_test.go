@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccStyleResource_basic(t *testing.T) {
+	resourceName := "mapbox_style.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	name := "test-style"
+
+	if os.Getenv("MOCK") != "" {
+		styleEndpoint := fmt.Sprintf("styles/v1/%s", username)
+		id := "cjz5g2fue0ed61cp6wy7tn1xe"
+
+		// Mapbox adds its own fields and reorders keys on every save.
+		styleDocument := func(color, modified string) string {
+			return fmt.Sprintf(`{
+  "version": 8,
+  "name": %[1]q,
+  "id": %[2]q,
+  "owner": %[3]q,
+  "created": "2024-01-01T00:00:00.000Z",
+  "modified": %[5]q,
+  "visibility": "private",
+  "layers": [{"type": "background", "paint": {"background-color": %[4]q}, "id": "background"}],
+  "sources": {}
+}`, name, id, username, color, modified)
+		}
+
+		current := styleDocument("#000000", "2024-01-01T00:00:00.000Z")
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Post(styleEndpoint).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusCreated).
+			Map(mockBody(&current))
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("%s/%s", styleEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(mockBody(&current))
+
+		gock.New("https://api.mapbox.com").
+			Patch(fmt.Sprintf("%s/%s", styleEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				current = styleDocument("#ffffff", "2024-01-02T00:00:00.000Z")
+				return mockBody(&current)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Delete(fmt.Sprintf("%s/%s", styleEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusNoContent)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccStyleResourceConfig(username, name, "#000000"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "username", username),
+					resource.TestCheckResourceAttr(resourceName, "owner", username),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "created"),
+					resource.TestCheckResourceAttrSet(resourceName, "modified"),
+					resource.TestMatchResourceAttr(resourceName, "url", regexp.MustCompile(fmt.Sprintf("^mapbox://styles/%s/", username))),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccUsernameImportStateId(resourceName),
+			},
+			// Update and Read testing
+			{
+				Config: testAccStyleResourceConfig(username, name, "#ffffff"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "username", username),
+					resource.TestCheckResourceAttrSet(resourceName, "modified"),
+				),
+			},
+			// Reformatted documents do not produce a diff
+			{
+				Config:   testAccStyleResourceConfigReformatted(username, name, "#ffffff"),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccStyleResource_draft(t *testing.T) {
+	resourceName := "mapbox_style.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	name := "test-style-draft"
+
+	if os.Getenv("MOCK") != "" {
+		styleEndpoint := fmt.Sprintf("styles/v1/%s", username)
+		id := "cjz5g2fue0ed61cp6wy7tn1xf"
+
+		styleDocument := func(color string) string {
+			return fmt.Sprintf(`{
+  "version": 8,
+  "name": %[1]q,
+  "id": %[2]q,
+  "owner": %[3]q,
+  "created": "2024-01-01T00:00:00.000Z",
+  "modified": "2024-01-01T00:00:00.000Z",
+  "layers": [{"id": "background", "type": "background", "paint": {"background-color": %[4]q}}],
+  "sources": {}
+}`, name, id, username, color)
+		}
+
+		published := styleDocument("#000000")
+		draft := published
+		defer gock.OffAll()
+
+		// The draft and publish mocks go first, the style mocks would match their paths too.
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("%s/%s/draft", styleEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(mockBody(&draft))
+
+		gock.New("https://api.mapbox.com").
+			Patch(fmt.Sprintf("%s/%s/draft", styleEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				draft = styleDocument("#ffffff")
+				return mockBody(&draft)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Post(fmt.Sprintf("%s/%s/publish", styleEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				published = draft
+				return mockBody(&published)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Post(styleEndpoint).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusCreated).
+			Map(mockBody(&published))
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("%s/%s", styleEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(mockBody(&published))
+
+		gock.New("https://api.mapbox.com").
+			Delete(fmt.Sprintf("%s/%s", styleEndpoint, id)).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusNoContent)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create is published
+			{
+				Config: testAccStyleResourceConfigPublish(username, name, "#000000", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "publish", "false"),
+					resource.TestMatchResourceAttr(resourceName, "published_style", regexp.MustCompile("#000000")),
+				),
+			},
+			// Updates are written to the draft
+			{
+				Config: testAccStyleResourceConfigPublish(username, name, "#ffffff", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "style", regexp.MustCompile("#ffffff")),
+					resource.TestMatchResourceAttr(resourceName, "published_style", regexp.MustCompile("#000000")),
+				),
+			},
+			// Publishing promotes the draft
+			{
+				Config: testAccStyleResourceConfigPublish(username, name, "#ffffff", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "publish", "true"),
+					resource.TestMatchResourceAttr(resourceName, "published_style", regexp.MustCompile("#ffffff")),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccStyleResource_invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mapbox_style" "test" {
+  username = "test"
+  style = jsonencode({
+    version = 8
+    name    = "invalid"
+    sources = {}
+    layers  = [{ id = "water", type = "fill", source = "composite" }]
+  })
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`layer "water": source "composite" does not exist`),
+			},
+		},
+	})
+}
+
+func testAccStyleResourceConfig(username, name, color string) string {
+	return fmt.Sprintf(`
+resource "mapbox_style" "test" {
+  username = %[1]q
+  style = jsonencode({
+    version = 8
+    name    = %[2]q
+    sources = {}
+    layers = [
+      {
+        id    = "background"
+        type  = "background"
+        paint = { "background-color" = %[3]q }
+      }
+    ]
+  })
+}
+`, username, name, color)
+}
+
+func testAccStyleResourceConfigReformatted(username, name, color string) string {
+	return fmt.Sprintf(`
+resource "mapbox_style" "test" {
+  username = %[1]q
+  style    = <<EOT
+{
+  "layers": [{"paint": {"background-color": %[3]q}, "type": "background", "id": "background"}],
+  "sources": {},
+  "name": %[2]q,
+  "version": 8.0
+}
+EOT
+}
+`, username, name, color)
+}
+
+func testAccStyleResourceConfigPublish(username, name, color string, publish bool) string {
+	return fmt.Sprintf(`
+resource "mapbox_style" "test" {
+  username = %[1]q
+  publish  = %[4]t
+  style = jsonencode({
+    version = 8
+    name    = %[2]q
+    sources = {}
+    layers = [
+      {
+        id    = "background"
+        type  = "background"
+        paint = { "background-color" = %[3]q }
+      }
+    ]
+  })
+}
+`, username, name, color, publish)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &StylesDataSource{}
+
+func NewStylesDataSource() datasource.DataSource {
+	return &StylesDataSource{}
+}
+
+// StylesDataSource defines the data source implementation.
+type StylesDataSource struct {
+	client *Client
+}
+
+// StylesDataSourceModel describes the data source data model.
+type StylesDataSourceModel struct {
+	Draft     types.Bool          `tfsdk:"draft"`
+	Id        types.String        `tfsdk:"id"`
+	Name      types.String        `tfsdk:"name"`
+	NameRegex types.String        `tfsdk:"name_regex"`
+	Styles    []StyleSummaryModel `tfsdk:"styles"`
+	Username  types.String        `tfsdk:"username"`
+}
+
+// StyleSummaryModel describes a style in the list of the data source.
+type StyleSummaryModel struct {
+	Created    types.String `tfsdk:"created"`
+	Id         types.String `tfsdk:"id"`
+	Modified   types.String `tfsdk:"modified"`
+	Name       types.String `tfsdk:"name"`
+	Owner      types.String `tfsdk:"owner"`
+	Visibility types.String `tfsdk:"visibility"`
+}
+
+// styleSummary is a style as returned by the list endpoint of the Styles API.
+type styleSummary struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Owner      string `json:"owner"`
+	Visibility string `json:"visibility"`
+	Created    string `json:"created"`
+	Modified   string `json:"modified"`
+}
+
+func (d *StylesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_styles"
+}
+
+func (d *StylesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the styles of an account, optionally filtered by name.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account to list the styles of.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only list styles with exactly this name.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list styles whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"draft": schema.BoolAttribute{
+				MarkdownDescription: "List the draft versions of the styles instead of the published ones.",
+				Optional:            true,
+			},
+			"styles": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching styles.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the style.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the style.",
+						},
+						"owner": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The username of the style owner.",
+						},
+						"visibility": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the style is `public` or `private`.",
+						},
+						"created": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The date and time the style was created.",
+						},
+						"modified": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The date and time the style was last modified.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username the styles were listed for.",
+			},
+		},
+	}
+}
+
+func (d *StylesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *StylesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data StylesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRe *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+		nameRe = re
+	}
+
+	endpoint := fmt.Sprintf("styles/v1/%s", data.Username.ValueString())
+	if data.Draft.ValueBool() {
+		endpoint += "?draft=true"
+	}
+
+	data.Styles = []StyleSummaryModel{}
+
+	err := d.client.ListPages(endpoint, func(body []byte) error {
+		var page []styleSummary
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode styles: %w", err)
+		}
+
+		for _, style := range page {
+			if !data.Name.IsNull() && style.Name != data.Name.ValueString() {
+				continue
+			}
+			if nameRe != nil && !nameRe.MatchString(style.Name) {
+				continue
+			}
+
+			data.Styles = append(data.Styles, StyleSummaryModel{
+				Created:    types.StringValue(style.Created),
+				Id:         types.StringValue(style.Id),
+				Modified:   types.StringValue(style.Modified),
+				Name:       types.StringValue(style.Name),
+				Owner:      types.StringValue(style.Owner),
+				Visibility: types.StringValue(style.Visibility),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list styles, got error: %s", err))
+		return
+	}
+
+	data.Id = data.Username
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccStylesDataSource_basic(t *testing.T) {
+	dataSourceName := "data.mapbox_styles.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+
+	if os.Getenv("MOCK") != "" {
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("styles/v1/%s", username)).
+			MatchParam("access_token", "test-token").
+			MatchParam("draft", "true").
+			MatchParam("start", "cjz5g2fue0ed61cp6wy7tn1xf").
+			Persist().
+			Reply(http.StatusOK).
+			BodyString(`[
+  {"id": "cjz5g2fue0ed61cp6wy7tn1xg", "name": "Basemap v2", "owner": "test-token", "visibility": "private", "created": "2024-02-01T10:00:00.000Z", "modified": "2024-02-03T10:00:00.000Z"}
+]`)
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("styles/v1/%s", username)).
+			MatchParam("access_token", "test-token").
+			MatchParam("draft", "true").
+			Persist().
+			Reply(http.StatusOK).
+			SetHeader("Link", fmt.Sprintf(`<https://api.mapbox.com/styles/v1/%s?draft=true&start=cjz5g2fue0ed61cp6wy7tn1xf&access_token=test-token>; rel="next"`, username)).
+			BodyString(`[
+  {"id": "cjz5g2fue0ed61cp6wy7tn1xe", "name": "Basemap", "owner": "test-token", "visibility": "public", "created": "2024-01-01T10:00:00.000Z", "modified": "2024-01-02T10:00:00.000Z"},
+  {"id": "cjz5g2fue0ed61cp6wy7tn1xf", "name": "Satellite", "owner": "test-token", "visibility": "private", "created": "2024-01-05T10:00:00.000Z", "modified": "2024-01-06T10:00:00.000Z"}
+]`)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "mapbox_styles" "test" {
+  username   = %q
+  name_regex = "^Basemap"
+  draft      = true
+}
+`, username),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "styles.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "styles.0.id", "cjz5g2fue0ed61cp6wy7tn1xe"),
+					resource.TestCheckResourceAttr(dataSourceName, "styles.0.visibility", "public"),
+					resource.TestCheckResourceAttr(dataSourceName, "styles.1.name", "Basemap v2"),
+					resource.TestCheckResourceAttr(dataSourceName, "styles.1.modified", "2024-02-03T10:00:00.000Z"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,903 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TilesetResource{}
+var _ resource.ResourceWithImportState = &TilesetResource{}
+var _ resource.ResourceWithModifyPlan = &TilesetResource{}
+var _ resource.ResourceWithValidateConfig = &TilesetResource{}
+
+// tilesetJobPollInterval is how often the stage of a publish job is checked.
+var tilesetJobPollInterval = 10 * time.Second
+
+const (
+	publishModeFull        = "full"
+	publishModeIncremental = "incremental"
+)
+
+var changesetIdRe = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// maxChangesetIdLength is the longest ID the Mapbox Tiling Service accepts for changesets.
+const maxChangesetIdLength = 32
+
+func NewTilesetResource() resource.Resource {
+	return &TilesetResource{}
+}
+
+// TilesetResource defines the resource implementation.
+type TilesetResource struct {
+	client *Client
+}
+
+// TilesetResourceModel describes the resource data model.
+type TilesetResourceModel struct {
+	ChangesetHashes types.Map      `tfsdk:"changeset_hashes"`
+	Changesets      types.Map      `tfsdk:"changesets"`
+	Description     types.String   `tfsdk:"description"`
+	Id              types.String   `tfsdk:"id"`
+	JobId           types.String   `tfsdk:"job_id"`
+	Layer           types.List     `tfsdk:"layer"`
+	Name            types.String   `tfsdk:"name"`
+	Private         types.Bool     `tfsdk:"private"`
+	PublishMode     types.String   `tfsdk:"publish_mode"`
+	PublishTriggers types.Map      `tfsdk:"publish_triggers"`
+	Recipe          types.String   `tfsdk:"recipe"`
+	TilesetId       types.String   `tfsdk:"tileset_id"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	UpdateMode      types.String   `tfsdk:"update_mode"`
+}
+
+// tilesetJob is a publish job of the Mapbox Tiling Service. Timestamps are in milliseconds
+// since the epoch.
+type tilesetJob struct {
+	Id         string            `json:"id"`
+	Stage      string            `json:"stage"`
+	Created    int64             `json:"created"`
+	Completed  int64             `json:"completed"`
+	Published  int64             `json:"published"`
+	Errors     []json.RawMessage `json:"errors"`
+	Warnings   []json.RawMessage `json:"warnings"`
+	LayerStats json.RawMessage   `json:"layer_stats"`
+	Tilestats  json.RawMessage   `json:"tilestats"`
+}
+
+func (r *TilesetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tileset"
+}
+
+func (r *TilesetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Mapbox Tiling Service tileset and its recipe. The tileset is published on create and whenever the recipe or `publish_triggers` change, waiting for the publish job to finish. Changed changesets are published with incremental update jobs instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"tileset_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the tileset in the form `USERNAME.TILESET-NAME`, the name being up to 32 characters of letters, numbers, `-` and `_`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"recipe": schema.StringAttribute{
+				MarkdownDescription: "The tileset recipe as JSON, see the [recipe reference](https://docs.mapbox.com/mapbox-tiling-service/reference/). New recipes are checked with the `validateRecipe` endpoint during plan. Either `recipe` or `layer` blocks must be set, when using `layer` blocks this is the compiled recipe.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the tileset.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of the tileset.",
+				Optional:            true,
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the tileset is private. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"publish_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that publish the tileset again when they change, e.g. the `file_hashes` of its tileset sources.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"update_mode": schema.StringAttribute{
+				MarkdownDescription: "How changed `changesets` are published. `full` publishes the whole tileset from its sources, `incremental` only processes the features of the changed changesets. Recipe and `publish_triggers` changes always publish the whole tileset. Defaults to `full`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(publishModeFull),
+			},
+			"changesets": schema.MapAttribute{
+				MarkdownDescription: "Paths of line-delimited GeoJSON files with the added, changed and deleted features of a layer, keyed by recipe layer name. Features are matched by their `id`. Changed files are uploaded as changesets and published with an incremental update job, creating the tileset publishes it in full from its sources. Requires `update_mode` to be `incremental`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"changeset_hashes": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The SHA-256 hashes of the changeset files, keyed by layer name.",
+			},
+			"job_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the last publish job.",
+			},
+			"publish_mode": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the last publish job was a `full` publish or an `incremental` update. Plans show the mode of the planned job.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the tileset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+
+		Blocks: map[string]schema.Block{
+			"layer": tilesetLayerBlock(),
+		},
+	}
+}
+
+func (r *TilesetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that the recipe is set either as JSON or as layer blocks, and checks
+// its structure so obvious mistakes fail the plan even when the validateRecipe endpoint cannot
+// be reached.
+func (r *TilesetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recipe, updateMode types.String
+	var layers types.List
+	var changesets types.Map
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("recipe"), &recipe)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("layer"), &layers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("update_mode"), &updateMode)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("changesets"), &changesets)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !updateMode.IsUnknown() {
+		switch updateMode.ValueString() {
+		case "", publishModeFull:
+			if !changesets.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("changesets"), "Changesets Require Incremental Updates", "Changesets can only be set when `update_mode` is `incremental`.")
+			}
+		case publishModeIncremental:
+			if changesets.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("changesets"), "Missing Changesets", "Incremental updates publish the features of `changesets`, which must be set.")
+			}
+		default:
+			resp.Diagnostics.AddAttributeError(path.Root("update_mode"), "Invalid Update Mode", fmt.Sprintf("The update mode must be `full` or `incremental`, got: %s", updateMode.ValueString()))
+		}
+	}
+
+	hasLayers := layers.IsUnknown() || len(layers.Elements()) > 0
+
+	switch {
+	case !recipe.IsNull() && hasLayers:
+		resp.Diagnostics.AddAttributeError(path.Root("layer"), "Conflicting Recipe", "Only one of `recipe` and `layer` blocks can be set.")
+		return
+	case recipe.IsNull() && !hasLayers:
+		resp.Diagnostics.AddAttributeError(path.Root("recipe"), "Missing Recipe", "One of `recipe` and `layer` blocks must be set.")
+		return
+	}
+
+	recipePath := path.Root("recipe")
+
+	if hasLayers {
+		var diags diag.Diagnostics
+
+		recipePath = path.Root("layer")
+		recipe, diags = compileRecipe(ctx, layers)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if resp.Diagnostics.HasError() || recipe.IsNull() || recipe.IsUnknown() {
+		return
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(recipe.ValueString()), &doc); err != nil {
+		resp.Diagnostics.AddAttributeError(recipePath, "Invalid Recipe", fmt.Sprintf("The recipe is not a JSON object: %s", err))
+		return
+	}
+
+	for _, recipeErr := range validateRecipeDocument(doc) {
+		resp.Diagnostics.AddAttributeError(recipePath, "Invalid Recipe", recipeErr.Error())
+	}
+
+	recipeLayers, _ := doc["layers"].(map[string]any)
+	for layer := range changesets.Elements() {
+		if _, ok := recipeLayers[layer]; !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("changesets").AtMapKey(layer), "Unknown Changeset Layer", fmt.Sprintf("The recipe has no layer %q.", layer))
+		}
+	}
+}
+
+// ModifyPlan validates new recipes with the API, ignores formatting only recipe changes and
+// plans a new job ID when the tileset is going to be published.
+func (r *TilesetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state TilesetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recipePath := path.Root("recipe")
+
+	if len(plan.Layer.Elements()) > 0 || plan.Layer.IsUnknown() {
+		var diags diag.Diagnostics
+
+		recipePath = path.Root("layer")
+		plan.Recipe, diags = compileRecipe(ctx, plan.Layer)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("recipe"), plan.Recipe)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	hashes, diags := plan.hashChangesets(ctx)
+	resp.Diagnostics.Append(diags...)
+	plan.ChangesetHashes = hashes
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("changeset_hashes"), hashes)...)
+
+	recipeChanged := req.State.Raw.IsNull() || !jsonEqual(plan.Recipe.ValueString(), state.Recipe.ValueString())
+
+	if !plan.Recipe.IsUnknown() && recipeChanged && r.client != nil {
+		resp.Diagnostics.Append(validateRecipe(ctx, r.client, plan.Recipe.ValueString(), recipePath)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("publish_mode"), publishModeFull)...)
+		return
+	}
+
+	if !plan.Recipe.IsUnknown() && !recipeChanged {
+		plan.Recipe = state.Recipe
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("recipe"), state.Recipe)...)
+	}
+
+	jobId, publishMode := state.JobId, state.PublishMode
+
+	switch mode := plan.publishMode(state); mode {
+	case publishModeFull:
+		jobId, publishMode = types.StringUnknown(), types.StringValue(mode)
+		resp.Diagnostics.AddWarning("Tileset Publish", fmt.Sprintf("Tileset %s is published in full from its sources.", state.Id.ValueString()))
+	case publishModeIncremental:
+		jobId, publishMode = types.StringUnknown(), types.StringValue(mode)
+		resp.Diagnostics.AddWarning("Tileset Publish", fmt.Sprintf("Tileset %s is updated incrementally from the changesets of the layers: %s.", state.Id.ValueString(), strings.Join(plan.changedChangesets(state), ", ")))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("job_id"), jobId)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("publish_mode"), publishMode)...)
+}
+
+func (r *TilesetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TilesetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 60*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var recipe any
+	if err := json.Unmarshal([]byte(data.Recipe.ValueString()), &recipe); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("recipe"), "Parsing Error", fmt.Sprintf("Unable to parse recipe, got error: %s", err))
+		return
+	}
+
+	body := data.info()
+	body["recipe"] = recipe
+
+	bytedata, err := json.Marshal(body)
+	if err != nil {
+		resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Unable to encode tileset, got error: %s", err))
+		return
+	}
+
+	createResp, err := r.client.Post(tilesetEndpoint(data.TilesetId.ValueString()), bytes.NewBuffer(bytedata))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create tileset, got error: %s", err))
+		return
+	}
+	_ = createResp.Body.Close()
+
+	data.Id = data.TilesetId
+
+	tflog.Trace(ctx, "created a tileset", map[string]any{"id": data.Id.ValueString()})
+
+	// The tileset exists from here on, so it is kept in state even when publishing fails.
+	data.JobId = types.StringNull()
+	data.PublishMode = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	resp.Diagnostics.Append(r.publish(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TilesetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TilesetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recipe, err := getTilesetRecipe(r.client, data.Id.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "tileset not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tileset recipe, got error: %s", err))
+		return
+	}
+
+	// The configured recipe is kept unless the recipe was changed outside of Terraform.
+	if !jsonEqual(recipe, data.Recipe.ValueString()) {
+		data.Recipe = types.StringValue(recipe)
+	}
+
+	tileset, found, err := getTileset(r.client, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tileset, got error: %s", err))
+		return
+	}
+
+	if found {
+		data.Name = types.StringValue(tileset.Name)
+		data.Private = types.BoolValue(tileset.Visibility != "public")
+
+		// Tilesets without a description are listed with an empty one, which keeps it unset.
+		if !data.Description.IsNull() || tileset.Description != "" {
+			data.Description = types.StringValue(tileset.Description)
+		}
+	} else {
+		tflog.Warn(ctx, "tileset not listed yet, keeping its name, description and visibility", map[string]any{"id": data.Id.ValueString()})
+	}
+
+	data.TilesetId = data.Id
+
+	if data.Private.IsNull() {
+		data.Private = types.BoolValue(true)
+	}
+
+	if data.UpdateMode.IsNull() {
+		data.UpdateMode = types.StringValue(publishModeFull)
+	}
+
+	// Imported tilesets have no layer blocks, which is an empty list rather than null.
+	if data.Layer.IsNull() {
+		data.Layer = types.ListValueMust(tilesetLayerBlock().NestedObject.Type(), []attr.Value{})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TilesetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state TilesetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 60*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	endpoint := tilesetEndpoint(data.Id.ValueString())
+
+	if !data.Name.Equal(state.Name) || !data.Description.Equal(state.Description) || !data.Private.Equal(state.Private) {
+		bytedata, err := json.Marshal(data.info())
+		if err != nil {
+			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Unable to encode tileset, got error: %s", err))
+			return
+		}
+
+		updateResp, err := r.client.Patch(endpoint, bytes.NewBuffer(bytedata))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update tileset, got error: %s", err))
+			return
+		}
+		_ = updateResp.Body.Close()
+	}
+
+	if !jsonEqual(data.Recipe.ValueString(), state.Recipe.ValueString()) {
+		updateResp, err := r.client.Patch(endpoint+"/recipe", bytes.NewBufferString(data.Recipe.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("recipe"), "Client Error", fmt.Sprintf("Unable to update tileset recipe, got error: %s", err))
+			return
+		}
+		_ = updateResp.Body.Close()
+	}
+
+	switch data.publishMode(state) {
+	case publishModeFull:
+		resp.Diagnostics.Append(r.publish(ctx, &data)...)
+	case publishModeIncremental:
+		resp.Diagnostics.Append(r.publishChangesets(ctx, &data, data.changedChangesets(state))...)
+	default:
+		data.JobId = state.JobId
+		data.PublishMode = state.PublishMode
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TilesetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TilesetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := r.client.Delete(tilesetEndpoint(data.Id.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete tileset, got error: %s", err))
+		return
+	}
+
+	_ = deleteResp.Body.Close()
+}
+
+func (r *TilesetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// publish starts a publish job and waits for it to finish.
+func (r *TilesetResource) publish(ctx context.Context, data *TilesetResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	publishResp, err := r.client.Post(tilesetEndpoint(data.Id.ValueString())+"/publish", nil)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to publish tileset, got error: %s", err))
+		return diags
+	}
+
+	data.PublishMode = types.StringValue(publishModeFull)
+
+	return r.awaitJob(ctx, data, publishResp)
+}
+
+// publishChangesets uploads the changeset files of the given layers and starts an
+// incremental update job with them, waiting for it to finish.
+func (r *TilesetResource) publishChangesets(ctx context.Context, data *TilesetResourceModel, layers []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var files map[string]string
+	diags.Append(data.Changesets.ElementsAs(ctx, &files, false)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	username, name, _ := strings.Cut(data.Id.ValueString(), ".")
+	changesets := map[string]any{}
+
+	for _, layer := range layers {
+		changesetId := tilesetChangesetId(name, layer)
+
+		var changeset tilesetSource
+		err := uploadTilesetSourceFile(r.client, http.MethodPost, fmt.Sprintf("tilesets/v1/changesets/%s/%s", username, changesetId), files[layer], &changeset)
+		if err != nil {
+			diags.AddAttributeError(path.Root("changesets").AtMapKey(layer), "Client Error", fmt.Sprintf("Unable to upload changeset, got error: %s", err))
+			return diags
+		}
+
+		tflog.Debug(ctx, "uploaded tileset changeset", map[string]any{"id": data.Id.ValueString(), "layer": layer, "changeset": changesetId})
+
+		changesets[layer] = map[string]any{"changeset": fmt.Sprintf("mapbox://tileset-changeset/%s/%s", username, changesetId)}
+	}
+
+	bytedata, err := json.Marshal(map[string]any{"layers": changesets})
+	if err != nil {
+		diags.AddError("Parsing Error", fmt.Sprintf("Unable to encode changesets, got error: %s", err))
+		return diags
+	}
+
+	publishResp, err := r.client.Post(tilesetEndpoint(data.Id.ValueString())+"/publish-changesets", bytes.NewBuffer(bytedata))
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to publish changesets, got error: %s", err))
+		return diags
+	}
+
+	data.PublishMode = types.StringValue(publishModeIncremental)
+
+	return r.awaitJob(ctx, data, publishResp)
+}
+
+// awaitJob waits for the job started with publishResp to finish, reporting the errors and
+// warnings of the job as diagnostics.
+func (r *TilesetResource) awaitJob(ctx context.Context, data *TilesetResourceModel, publishResp *http.Response) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var published struct {
+		JobId string `json:"jobId"`
+	}
+	if err := decodeJSON(publishResp, &published); err != nil {
+		diags.AddError("Unmarshall Error", fmt.Sprintf("Unable to publish tileset, got error: %s", err))
+		return diags
+	}
+
+	data.JobId = types.StringValue(published.JobId)
+
+	tflog.Info(ctx, "waiting for tileset publish job", map[string]any{"id": data.Id.ValueString(), "job_id": published.JobId})
+
+	job, err := waitForTilesetJob(ctx, r.client, data.Id.ValueString(), published.JobId)
+	if err != nil {
+		diags.AddError("Tileset Job Error", fmt.Sprintf("Unable to wait for publish job %s, got error: %s", published.JobId, err))
+		return diags
+	}
+
+	for _, warning := range job.Warnings {
+		diags.AddWarning("Tileset Job Warning", jobMessage(warning))
+	}
+
+	switch job.Stage {
+	case "success":
+	case "superseded":
+		diags.AddWarning("Tileset Job Superseded", fmt.Sprintf("Publish job %s was superseded by a newer job for the tileset.", job.Id))
+	default:
+		if len(job.Errors) == 0 {
+			diags.AddError("Tileset Job Error", fmt.Sprintf("Publish job %s finished in stage %q.", job.Id, job.Stage))
+		}
+		for _, jobErr := range job.Errors {
+			diags.AddError("Tileset Job Error", jobMessage(jobErr))
+		}
+	}
+
+	return diags
+}
+
+// publishMode returns the kind of job the changes from state to data require, or an empty
+// string when the tileset does not need to be published.
+func (data TilesetResourceModel) publishMode(state TilesetResourceModel) string {
+	switch {
+	case data.Recipe.IsUnknown() || !jsonEqual(data.Recipe.ValueString(), state.Recipe.ValueString()),
+		!data.PublishTriggers.Equal(state.PublishTriggers):
+		return publishModeFull
+	case len(data.changedChangesets(state)) > 0:
+		return publishModeIncremental
+	}
+
+	return ""
+}
+
+// changedChangesets returns the layers whose changeset files changed from state to data.
+func (data TilesetResourceModel) changedChangesets(state TilesetResourceModel) []string {
+	var layers []string
+
+	if data.ChangesetHashes.IsUnknown() {
+		for layer := range data.Changesets.Elements() {
+			layers = append(layers, layer)
+		}
+	}
+
+	previous := state.ChangesetHashes.Elements()
+	for layer, hash := range data.ChangesetHashes.Elements() {
+		if !hash.Equal(previous[layer]) {
+			layers = append(layers, layer)
+		}
+	}
+
+	sort.Strings(layers)
+	return layers
+}
+
+// hashChangesets hashes the changeset files, keyed by layer name.
+func (data TilesetResourceModel) hashChangesets(ctx context.Context) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if data.Changesets.IsNull() {
+		return types.MapNull(types.StringType), diags
+	}
+	if !isFullyKnown(ctx, data.Changesets) {
+		return types.MapUnknown(types.StringType), diags
+	}
+
+	var files map[string]string
+	diags.Append(data.Changesets.ElementsAs(ctx, &files, false)...)
+
+	hashes := make(map[string]string, len(files))
+	for layer, p := range files {
+		hash, err := hashFile(p)
+		if err != nil {
+			diags.AddAttributeError(path.Root("changesets").AtMapKey(layer), "Unable to Read File", err.Error())
+			continue
+		}
+
+		hashes[layer] = hash
+	}
+
+	if diags.HasError() {
+		return types.MapNull(types.StringType), diags
+	}
+
+	value, d := types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(d...)
+
+	return value, diags
+}
+
+// info returns the fields of the tileset that are not part of the recipe.
+func (data TilesetResourceModel) info() map[string]any {
+	info := map[string]any{
+		"name":    data.Name.ValueString(),
+		"private": data.Private.ValueBool(),
+	}
+
+	if !data.Description.IsNull() {
+		info["description"] = data.Description.ValueString()
+	}
+
+	return info
+}
+
+func waitForTilesetJob(ctx context.Context, client *Client, tilesetId, jobId string) (tilesetJob, error) {
+	for {
+		var job tilesetJob
+
+		resp, err := client.Get(fmt.Sprintf("%s/jobs/%s", tilesetEndpoint(tilesetId), jobId))
+		if err != nil {
+			return job, err
+		}
+
+		if err := decodeJSON(resp, &job); err != nil {
+			return job, err
+		}
+
+		if job.Stage != "queued" && job.Stage != "processing" {
+			return job, nil
+		}
+
+		tflog.Debug(ctx, "tileset publish job is not done yet", map[string]any{"job_id": jobId, "stage": job.Stage})
+
+		select {
+		case <-ctx.Done():
+			return job, fmt.Errorf("job still %s: %w", job.Stage, ctx.Err())
+		case <-time.After(tilesetJobPollInterval):
+		}
+	}
+}
+
+// jobMessage turns an entry of the errors or warnings of a job into text. Entries are
+// usually strings, but may be objects.
+func jobMessage(raw json.RawMessage) string {
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return message
+	}
+
+	return strings.TrimSpace(string(raw))
+}
+
+func getTilesetRecipe(client *Client, tilesetId string) (string, error) {
+	resp, err := client.Get(tilesetEndpoint(tilesetId) + "/recipe")
+	if err != nil {
+		return "", err
+	}
+
+	var body struct {
+		Recipe json.RawMessage `json:"recipe"`
+	}
+	if err := decodeJSON(resp, &body); err != nil {
+		return "", err
+	}
+
+	return string(body.Recipe), nil
+}
+
+// getTileset looks the tileset up in the tilesets of its owner, since the Tilesets API has no
+// endpoint for the name, description and visibility of a single tileset.
+func getTileset(client *Client, tilesetId string) (tilesetSummary, bool, error) {
+	var (
+		tileset tilesetSummary
+		found   bool
+	)
+
+	username, _, _ := strings.Cut(tilesetId, ".")
+
+	err := client.ListPages(fmt.Sprintf("tilesets/v1/%s?limit=500", username), func(body []byte) error {
+		if found {
+			return nil
+		}
+
+		var page []tilesetSummary
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode tilesets: %w", err)
+		}
+
+		for _, summary := range page {
+			if summary.Id == tilesetId {
+				tileset, found = summary, true
+				return nil
+			}
+		}
+
+		return nil
+	})
+
+	return tileset, found, err
+}
+
+// validateRecipe checks a recipe with the validateRecipe endpoint. When the API cannot be
+// reached or is rate limited the plan continues with a warning, relying on the checks of
+// ValidateConfig.
+func validateRecipe(ctx context.Context, client *Client, recipe string, recipePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	resp, err := client.Put("tilesets/v1/validateRecipe", bytes.NewBufferString(recipe))
+
+	var apiErr Error
+	switch {
+	case err == nil:
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity):
+		diags.AddAttributeError(recipePath, "Invalid Recipe", err.Error())
+		return diags
+	case errors.As(err, &apiErr) && apiErr.StatusCode != http.StatusTooManyRequests && apiErr.StatusCode < http.StatusInternalServerError:
+		diags.AddError("Client Error", fmt.Sprintf("Unable to validate recipe, got error: %s", err))
+		return diags
+	default:
+		tflog.Warn(ctx, "unable to validate tileset recipe", map[string]any{"error": err.Error()})
+		diags.AddAttributeWarning(recipePath, "Recipe Not Validated", fmt.Sprintf("The recipe could not be validated by the Mapbox Tiling Service, only its structure was checked: %s", err))
+		return diags
+	}
+
+	var validation struct {
+		Valid    bool              `json:"valid"`
+		Errors   []json.RawMessage `json:"errors"`
+		Warnings []json.RawMessage `json:"warnings"`
+	}
+	if err := decodeJSON(resp, &validation); err != nil {
+		diags.AddError("Unmarshall Error", fmt.Sprintf("Unable to validate recipe, got error: %s", err))
+		return diags
+	}
+
+	for _, warning := range validation.Warnings {
+		diags.AddAttributeWarning(recipePath, "Recipe Warning", jobMessage(warning))
+	}
+
+	if validation.Valid {
+		return diags
+	}
+
+	if len(validation.Errors) == 0 {
+		diags.AddAttributeError(recipePath, "Invalid Recipe", "The Mapbox Tiling Service rejected the recipe without giving a reason.")
+	}
+	for _, validationErr := range validation.Errors {
+		diags.AddAttributeError(recipePath, "Invalid Recipe", jobMessage(validationErr))
+	}
+
+	return diags
+}
+
+// tilesetChangesetId derives the ID of the changeset of a layer from the tileset name, within
+// the 32 characters allowed for changeset IDs. Longer IDs are cut and end in a short hash of
+// the tileset name and layer, so layers with a common prefix keep distinct changesets.
+func tilesetChangesetId(tilesetName, layer string) string {
+	id := changesetIdRe.ReplaceAllString(tilesetName+"-"+layer, "-")
+	if len(id) <= maxChangesetIdLength {
+		return id
+	}
+
+	suffix := "-" + contentHash([]byte(tilesetName + "/" + layer))[:8]
+
+	return id[:maxChangesetIdLength-len(suffix)] + suffix
+}
+
+func tilesetEndpoint(tilesetId string) string {
+	return fmt.Sprintf("tilesets/v1/%s", tilesetId)
+}
+
+// jsonEqual reports whether two JSON documents hold the same values, ignoring formatting
+// and key order.
+func jsonEqual(a, b string) bool {
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return a == b
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
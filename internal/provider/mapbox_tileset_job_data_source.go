@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TilesetJobDataSource{}
+
+func NewTilesetJobDataSource() datasource.DataSource {
+	return &TilesetJobDataSource{}
+}
+
+// TilesetJobDataSource defines the data source implementation.
+type TilesetJobDataSource struct {
+	client *Client
+}
+
+// TilesetJobDataSourceModel describes the data source data model.
+type TilesetJobDataSourceModel struct {
+	Completed  types.String `tfsdk:"completed"`
+	Created    types.String `tfsdk:"created"`
+	Errors     []string     `tfsdk:"errors"`
+	Id         types.String `tfsdk:"id"`
+	JobId      types.String `tfsdk:"job_id"`
+	LayerStats types.String `tfsdk:"layer_stats"`
+	Published  types.String `tfsdk:"published"`
+	Stage      types.String `tfsdk:"stage"`
+	TilesetId  types.String `tfsdk:"tileset_id"`
+	Tilestats  types.String `tfsdk:"tilestats"`
+	Warnings   []string     `tfsdk:"warnings"`
+}
+
+func (d *TilesetJobDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tileset_job"
+}
+
+func (d *TilesetJobDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := tilesetJobAttributes()
+
+	attributes["tileset_id"] = schema.StringAttribute{
+		MarkdownDescription: "The ID of the tileset the job belongs to.",
+		Required:            true,
+	}
+	attributes["job_id"] = schema.StringAttribute{
+		MarkdownDescription: "The ID of the job. When not set, the newest job in `stage` is read.",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["stage"] = schema.StringAttribute{
+		MarkdownDescription: "The stage of the job, one of `queued`, `processing`, `success` or `failed`. When `job_id` is not set the newest job in this stage is read, otherwise reading fails unless the job is in this stage.",
+		Optional:            true,
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a publish job of a tileset, either by ID or the newest job in a stage, e.g. the last successful one.",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *TilesetJobDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TilesetJobDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TilesetJobDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stage := data.Stage.ValueString()
+	if stage != "" && !slices.Contains(tilesetJobStages, stage) {
+		resp.Diagnostics.AddAttributeError(path.Root("stage"), "Invalid Job Stage", fmt.Sprintf("The stage must be one of %s, got: %s", strings.Join(tilesetJobStages, ", "), stage))
+		return
+	}
+
+	tilesetId := data.TilesetId.ValueString()
+
+	var job tilesetJob
+
+	if !data.JobId.IsNull() {
+		httpResp, err := d.client.Get(fmt.Sprintf("%s/jobs/%s", tilesetEndpoint(tilesetId), data.JobId.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tileset job, got error: %s", err))
+			return
+		}
+
+		if err := decodeJSON(httpResp, &job); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tileset job, got error: %s", err))
+			return
+		}
+
+		if stage != "" && job.Stage != stage {
+			resp.Diagnostics.AddAttributeError(path.Root("stage"), "Unexpected Job Stage", fmt.Sprintf("Job %s of tileset %s is in stage %q, expected %q.", job.Id, tilesetId, job.Stage, stage))
+			return
+		}
+	} else {
+		jobs, err := listTilesetJobs(d.client, tilesetId, stage)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list tileset jobs, got error: %s", err))
+			return
+		}
+
+		if len(jobs) == 0 {
+			resp.Diagnostics.AddError("No Matching Job", fmt.Sprintf("Tileset %s has no jobs in stage %q.", tilesetId, stage))
+			return
+		}
+
+		job = jobs[0]
+	}
+
+	model := newTilesetJobModel(job)
+
+	data.Completed = model.Completed
+	data.Created = model.Created
+	data.Errors = model.Errors
+	data.Id = model.Id
+	data.JobId = model.Id
+	data.LayerStats = model.LayerStats
+	data.Published = model.Published
+	data.Stage = model.Stage
+	data.Tilestats = model.Tilestats
+	data.Warnings = model.Warnings
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccTilesetJobDataSource_basic(t *testing.T) {
+	dataSourceName := "data.mapbox_tileset_job.test"
+	tilesetId := os.Getenv("MAPBOX_TILESET_ID")
+
+	if os.Getenv("MOCK") != "" {
+		tilesetId = "test.points"
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("tilesets/v1/%s/jobs", tilesetId)).
+			MatchParam("access_token", "test-token").
+			MatchParam("stage", "success").
+			Persist().
+			Reply(http.StatusOK).
+			BodyString(`[
+  {"id": "job-2", "stage": "success", "created": 1704189600000, "completed": 1704189660000, "published": 1704189660000, "tilestats": {"layerCount": 1}},
+  {"id": "job-4", "stage": "success", "created": 1704362400000, "completed": 1704362460000, "published": 1704362460000, "tilestats": {"layerCount": 2}}
+]`)
+	} else if tilesetId == "" {
+		t.Skip("MAPBOX_TILESET_ID must be set to a tileset with a successful publish job")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The newest job in a stage
+			{
+				Config: fmt.Sprintf(`
+data "mapbox_tileset_job" "test" {
+  tileset_id = %q
+  stage      = "success"
+}
+`, tilesetId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "job_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "stage", "success"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "published"),
+					resource.TestCheckResourceAttr(dataSourceName, "errors.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTilesetJobDataSource_stageMismatch(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("job stages are only tested against mocks")
+	}
+
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Get("tilesets/v1/test.points/jobs/job-1").
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		BodyString(`{"id": "job-1", "stage": "failed", "created": 1704103200000, "errors": ["source is empty"]}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "mapbox_tileset_job" "test" {
+  tileset_id = "test.points"
+  job_id     = "job-1"
+  stage      = "success"
+}
+`,
+				ExpectError: regexp.MustCompile(`is in stage "failed", expected "success"`),
+			},
+		},
+	})
+}
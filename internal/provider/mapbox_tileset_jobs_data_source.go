@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TilesetJobsDataSource{}
+
+// tilesetJobStages are the stages jobs can be filtered by.
+var tilesetJobStages = []string{"queued", "processing", "success", "failed"}
+
+func NewTilesetJobsDataSource() datasource.DataSource {
+	return &TilesetJobsDataSource{}
+}
+
+// TilesetJobsDataSource defines the data source implementation.
+type TilesetJobsDataSource struct {
+	client *Client
+}
+
+// TilesetJobsDataSourceModel describes the data source data model.
+type TilesetJobsDataSourceModel struct {
+	Id        types.String      `tfsdk:"id"`
+	Jobs      []TilesetJobModel `tfsdk:"jobs"`
+	Stage     types.String      `tfsdk:"stage"`
+	TilesetId types.String      `tfsdk:"tileset_id"`
+}
+
+// TilesetJobModel describes a job in the list of the data source.
+type TilesetJobModel struct {
+	Completed  types.String `tfsdk:"completed"`
+	Created    types.String `tfsdk:"created"`
+	Errors     []string     `tfsdk:"errors"`
+	Id         types.String `tfsdk:"id"`
+	LayerStats types.String `tfsdk:"layer_stats"`
+	Published  types.String `tfsdk:"published"`
+	Stage      types.String `tfsdk:"stage"`
+	Tilestats  types.String `tfsdk:"tilestats"`
+	Warnings   []string     `tfsdk:"warnings"`
+}
+
+func (d *TilesetJobsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tileset_jobs"
+}
+
+func (d *TilesetJobsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the publish jobs of a tileset, newest first, optionally filtered by stage.",
+
+		Attributes: map[string]schema.Attribute{
+			"tileset_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the tileset to list the jobs of.",
+				Required:            true,
+			},
+			"stage": schema.StringAttribute{
+				MarkdownDescription: "Only list jobs in this stage, one of `queued`, `processing`, `success` or `failed`.",
+				Optional:            true,
+			},
+			"jobs": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching jobs, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: tilesetJobAttributes(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The tileset ID the jobs were listed for.",
+			},
+		},
+	}
+}
+
+// tilesetJobAttributes are the attributes describing a job, shared by both job data sources.
+func tilesetJobAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The ID of the job.",
+		},
+		"stage": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The stage of the job, e.g. `processing`, `success` or `failed`.",
+		},
+		"created": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The date and time the job was created.",
+		},
+		"completed": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The date and time the job finished, if it has.",
+		},
+		"published": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The date and time the tiles of the job were published, if they were.",
+		},
+		"errors": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "The errors the job failed with.",
+		},
+		"warnings": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "The warnings of the job, e.g. features that were dropped from tiles.",
+		},
+		"tilestats": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The statistics of the attributes in the tileset as JSON, use `jsondecode` to read them.",
+		},
+		"layer_stats": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The tile counts and sizes of each layer by zoom level as JSON, use `jsondecode` to read them.",
+		},
+	}
+}
+
+func (d *TilesetJobsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TilesetJobsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TilesetJobsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stage := data.Stage.ValueString()
+	if stage != "" && !slices.Contains(tilesetJobStages, stage) {
+		resp.Diagnostics.AddAttributeError(path.Root("stage"), "Invalid Job Stage", fmt.Sprintf("The stage must be one of %s, got: %s", strings.Join(tilesetJobStages, ", "), stage))
+		return
+	}
+
+	jobs, err := listTilesetJobs(d.client, data.TilesetId.ValueString(), stage)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list tileset jobs, got error: %s", err))
+		return
+	}
+
+	data.Jobs = make([]TilesetJobModel, 0, len(jobs))
+	for _, job := range jobs {
+		data.Jobs = append(data.Jobs, newTilesetJobModel(job))
+	}
+
+	data.Id = data.TilesetId
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listTilesetJobs lists the jobs of a tileset in the given stage, or all jobs when stage is
+// empty, newest first.
+func listTilesetJobs(client *Client, tilesetId, stage string) ([]tilesetJob, error) {
+	endpoint := tilesetEndpoint(tilesetId) + "/jobs"
+	if stage != "" {
+		endpoint += "?stage=" + url.QueryEscape(stage)
+	}
+
+	var jobs []tilesetJob
+
+	err := client.ListPages(endpoint, func(body []byte) error {
+		var page []tilesetJob
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode tileset jobs: %w", err)
+		}
+
+		jobs = append(jobs, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Created > jobs[j].Created
+	})
+
+	return jobs, nil
+}
+
+func newTilesetJobModel(job tilesetJob) TilesetJobModel {
+	model := TilesetJobModel{
+		Completed:  jobTime(job.Completed),
+		Created:    jobTime(job.Created),
+		Errors:     make([]string, 0, len(job.Errors)),
+		Id:         types.StringValue(job.Id),
+		LayerStats: jobStats(job.LayerStats),
+		Published:  jobTime(job.Published),
+		Stage:      types.StringValue(job.Stage),
+		Tilestats:  jobStats(job.Tilestats),
+		Warnings:   make([]string, 0, len(job.Warnings)),
+	}
+
+	for _, jobErr := range job.Errors {
+		model.Errors = append(model.Errors, jobMessage(jobErr))
+	}
+
+	for _, warning := range job.Warnings {
+		model.Warnings = append(model.Warnings, jobMessage(warning))
+	}
+
+	return model
+}
+
+// jobTime formats a job timestamp in milliseconds as RFC 3339, zero being unset.
+func jobTime(milliseconds int64) types.String {
+	if milliseconds == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(time.UnixMilli(milliseconds).UTC().Format(time.RFC3339))
+}
+
+// jobStats returns the statistics of a job as compact JSON.
+func jobStats(raw json.RawMessage) types.String {
+	var compact bytes.Buffer
+	if len(raw) == 0 || string(raw) == "null" || json.Compact(&compact, raw) != nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(compact.String())
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccTilesetJobsDataSource_basic(t *testing.T) {
+	dataSourceName := "data.mapbox_tileset_jobs.test"
+	tilesetId := os.Getenv("MAPBOX_TILESET_ID")
+
+	if os.Getenv("MOCK") != "" {
+		tilesetId = "test.points"
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("tilesets/v1/%s/jobs", tilesetId)).
+			MatchParam("access_token", "test-token").
+			MatchParam("stage", "failed").
+			Persist().
+			Reply(http.StatusOK).
+			BodyString(`[
+  {"id": "job-1", "stage": "failed", "created": 1704103200000, "completed": 1704103260000, "errors": ["source is empty"], "warnings": []},
+  {"id": "job-3", "stage": "failed", "created": 1704276000000, "completed": 1704276060000, "errors": [{"message": "layer size exceeded"}], "warnings": ["dropped features"], "layer_stats": {"points": {"total_tiles": 0}}}
+]`)
+	} else if tilesetId == "" {
+		t.Skip("MAPBOX_TILESET_ID must be set to a tileset with publish jobs")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "mapbox_tileset_jobs" "test" {
+  tileset_id = %q
+  stage      = "failed"
+}
+`, tilesetId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", tilesetId),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.0.id", "job-3"),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.0.created", "2024-01-03T10:00:00Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.0.errors.0", `{"message": "layer size exceeded"}`),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.0.warnings.0", "dropped features"),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.0.layer_stats", `{"points":{"total_tiles":0}}`),
+					resource.TestCheckNoResourceAttr(dataSourceName, "jobs.0.published"),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.1.id", "job-1"),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.1.errors.0", "source is empty"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TilesetMetadataDataSource{}
+
+func NewTilesetMetadataDataSource() datasource.DataSource {
+	return &TilesetMetadataDataSource{}
+}
+
+// TilesetMetadataDataSource defines the data source implementation.
+type TilesetMetadataDataSource struct {
+	client *Client
+}
+
+// TilesetMetadataDataSourceModel describes the data source data model.
+type TilesetMetadataDataSourceModel struct {
+	Attribution  types.String              `tfsdk:"attribution"`
+	Bounds       []float64                 `tfsdk:"bounds"`
+	Center       []float64                 `tfsdk:"center"`
+	Description  types.String              `tfsdk:"description"`
+	Format       types.String              `tfsdk:"format"`
+	Id           types.String              `tfsdk:"id"`
+	Maxzoom      types.Int64               `tfsdk:"maxzoom"`
+	Minzoom      types.Int64               `tfsdk:"minzoom"`
+	Name         types.String              `tfsdk:"name"`
+	Tilejson     types.String              `tfsdk:"tilejson"`
+	Tiles        []string                  `tfsdk:"tiles"`
+	TilesetIds   []string                  `tfsdk:"tileset_ids"`
+	VectorLayers []TilesetVectorLayerModel `tfsdk:"vector_layers"`
+}
+
+// TilesetVectorLayerModel describes a vector layer of the tileset.
+type TilesetVectorLayerModel struct {
+	Description types.String      `tfsdk:"description"`
+	Fields      map[string]string `tfsdk:"fields"`
+	Id          types.String      `tfsdk:"id"`
+	Maxzoom     types.Int64       `tfsdk:"maxzoom"`
+	Minzoom     types.Int64       `tfsdk:"minzoom"`
+	Source      types.String      `tfsdk:"source"`
+	SourceName  types.String      `tfsdk:"source_name"`
+}
+
+// tileJSON is the TileJSON document the Vector and Raster Tiles APIs return for a tileset.
+type tileJSON struct {
+	Attribution  string    `json:"attribution"`
+	Bounds       []float64 `json:"bounds"`
+	Center       []float64 `json:"center"`
+	Description  string    `json:"description"`
+	Format       string    `json:"format"`
+	Id           string    `json:"id"`
+	Maxzoom      int64     `json:"maxzoom"`
+	Minzoom      int64     `json:"minzoom"`
+	Name         string    `json:"name"`
+	Tilejson     string    `json:"tilejson"`
+	Tiles        []string  `json:"tiles"`
+	VectorLayers []struct {
+		Description string            `json:"description"`
+		Fields      map[string]string `json:"fields"`
+		Id          string            `json:"id"`
+		Maxzoom     int64             `json:"maxzoom"`
+		Minzoom     int64             `json:"minzoom"`
+		Source      string            `json:"source"`
+		SourceName  string            `json:"source_name"`
+	} `json:"vector_layers"`
+}
+
+func (d *TilesetMetadataDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tileset_metadata"
+}
+
+func (d *TilesetMetadataDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the TileJSON metadata of a tileset, or of a composite of several tilesets, such as its bounds, zoom range and the fields of its vector layers.",
+
+		Attributes: map[string]schema.Attribute{
+			"tileset_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the tilesets. More than one ID reads the metadata of the composite tileset, whose vector layers are those of all tilesets.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the tileset.",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The description of the tileset.",
+			},
+			"attribution": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The attribution to show with the tiles, as HTML.",
+			},
+			"tilejson": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version of the TileJSON specification of the document.",
+			},
+			"format": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The format of the tiles, e.g. `pbf` for vector tiles or `webp` for raster tiles.",
+			},
+			"bounds": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.Float64Type,
+				MarkdownDescription: "The extent of the tileset as west, south, east and north in degrees.",
+			},
+			"center": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.Float64Type,
+				MarkdownDescription: "The default view of the tileset as longitude, latitude and zoom level.",
+			},
+			"minzoom": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The lowest zoom level of the tiles.",
+			},
+			"maxzoom": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The highest zoom level of the tiles.",
+			},
+			"tiles": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The URL templates of the tiles.",
+			},
+			"vector_layers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The vector layers of the tiles, empty for raster tilesets.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the layer, as used for `source-layer` in styles.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The description of the layer.",
+						},
+						"fields": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The feature properties of the layer, keyed by name, with their type such as `Number`, `String` or `Boolean` as value.",
+						},
+						"minzoom": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The lowest zoom level the layer is in.",
+						},
+						"maxzoom": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The highest zoom level the layer is in.",
+						},
+						"source": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the tileset the layer comes from.",
+						},
+						"source_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the tileset the layer comes from.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The comma separated tileset IDs.",
+			},
+		},
+	}
+}
+
+func (d *TilesetMetadataDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TilesetMetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TilesetMetadataDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(data.TilesetIds) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("tileset_ids"), "Missing Tileset ID", "At least one tileset ID must be set.")
+		return
+	}
+
+	for _, tilesetId := range data.TilesetIds {
+		if tilesetId == "" || strings.ContainsAny(tilesetId, ",/") {
+			resp.Diagnostics.AddAttributeError(path.Root("tileset_ids"), "Invalid Tileset ID", fmt.Sprintf("%q is not a tileset ID.", tilesetId))
+			return
+		}
+	}
+
+	id := strings.Join(data.TilesetIds, ",")
+
+	httpResp, err := d.client.Get(fmt.Sprintf("v4/%s.json?secure", id))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tileset metadata, got error: %s", err))
+		return
+	}
+
+	var metadata tileJSON
+	if err := decodeJSON(httpResp, &metadata); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tileset metadata, got error: %s", err))
+		return
+	}
+
+	data.Attribution = types.StringValue(metadata.Attribution)
+	data.Bounds = metadata.Bounds
+	data.Center = metadata.Center
+	data.Description = types.StringValue(metadata.Description)
+	data.Format = types.StringValue(metadata.Format)
+	data.Id = types.StringValue(id)
+	data.Maxzoom = types.Int64Value(metadata.Maxzoom)
+	data.Minzoom = types.Int64Value(metadata.Minzoom)
+	data.Name = types.StringValue(metadata.Name)
+	data.Tilejson = types.StringValue(metadata.Tilejson)
+	data.Tiles = metadata.Tiles
+
+	data.VectorLayers = make([]TilesetVectorLayerModel, 0, len(metadata.VectorLayers))
+	for _, layer := range metadata.VectorLayers {
+		fields := layer.Fields
+		if fields == nil {
+			fields = map[string]string{}
+		}
+
+		data.VectorLayers = append(data.VectorLayers, TilesetVectorLayerModel{
+			Description: types.StringValue(layer.Description),
+			Fields:      fields,
+			Id:          types.StringValue(layer.Id),
+			Maxzoom:     types.Int64Value(layer.Maxzoom),
+			Minzoom:     types.Int64Value(layer.Minzoom),
+			Source:      types.StringValue(layer.Source),
+			SourceName:  types.StringValue(layer.SourceName),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccTilesetMetadataDataSource_composite(t *testing.T) {
+	dataSourceName := "data.mapbox_tileset_metadata.test"
+
+	if os.Getenv("MOCK") != "" {
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get("v4/mapbox.mapbox-streets-v8,mapbox.mapbox-terrain-v2.json").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			BodyString(`{
+  "tilejson": "2.2.0",
+  "id": "mapbox.mapbox-streets-v8,mapbox.mapbox-terrain-v2",
+  "name": "Mapbox Streets v8 + Mapbox Terrain v2",
+  "description": "",
+  "attribution": "<a href=\"https://www.mapbox.com/about/maps/\" target=\"_blank\">&copy; Mapbox</a>",
+  "format": "pbf",
+  "bounds": [-180, -85, 180, 85],
+  "center": [0, 0, 0],
+  "minzoom": 0,
+  "maxzoom": 16,
+  "tiles": ["https://a.tiles.mapbox.com/v4/mapbox.mapbox-streets-v8,mapbox.mapbox-terrain-v2/{z}/{x}/{y}.vector.pbf"],
+  "vector_layers": [
+    {"id": "road", "description": "", "minzoom": 3, "maxzoom": 16, "source": "mapbox.mapbox-streets-v8", "source_name": "Mapbox Streets v8", "fields": {"class": "String", "oneway": "Boolean"}},
+    {"id": "contour", "description": "", "minzoom": 9, "maxzoom": 15, "source": "mapbox.mapbox-terrain-v2", "source_name": "Mapbox Terrain v2", "fields": {"ele": "Number"}}
+  ]
+}`)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "mapbox_tileset_metadata" "test" {
+  tileset_ids = ["mapbox.mapbox-streets-v8", "mapbox.mapbox-terrain-v2"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "mapbox.mapbox-streets-v8,mapbox.mapbox-terrain-v2"),
+					resource.TestCheckResourceAttr(dataSourceName, "format", "pbf"),
+					resource.TestCheckResourceAttr(dataSourceName, "bounds.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "center.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "maxzoom", "16"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "tiles.0"),
+					resource.TestCheckResourceAttr(dataSourceName, "vector_layers.0.id", "road"),
+					resource.TestCheckResourceAttr(dataSourceName, "vector_layers.0.fields.class", "String"),
+					resource.TestCheckResourceAttr(dataSourceName, "vector_layers.0.source", "mapbox.mapbox-streets-v8"),
+					resource.TestCheckResourceAttr(dataSourceName, "vector_layers.1.id", "contour"),
+					resource.TestCheckResourceAttr(dataSourceName, "vector_layers.1.fields.ele", "Number"),
+					resource.TestCheckResourceAttr(dataSourceName, "vector_layers.1.source_name", "Mapbox Terrain v2"),
+				),
+			},
+		},
+	})
+}
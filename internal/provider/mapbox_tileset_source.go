@@ -0,0 +1,559 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TilesetSourceResource{}
+var _ resource.ResourceWithImportState = &TilesetSourceResource{}
+var _ resource.ResourceWithValidateConfig = &TilesetSourceResource{}
+var _ resource.ResourceWithModifyPlan = &TilesetSourceResource{}
+
+const (
+	tilesetSourceModeReplace = "replace"
+	tilesetSourceModeAppend  = "append"
+)
+
+// maxFeatureErrors is how many invalid features of a file are reported.
+const maxFeatureErrors = 10
+
+func NewTilesetSourceResource() resource.Resource {
+	return &TilesetSourceResource{}
+}
+
+// TilesetSourceResource defines the resource implementation.
+type TilesetSourceResource struct {
+	client *Client
+}
+
+// TilesetSourceResourceModel describes the resource data model.
+type TilesetSourceResourceModel struct {
+	FileHashes types.Map    `tfsdk:"file_hashes"`
+	FileSize   types.Int64  `tfsdk:"file_size"`
+	Files      types.Int64  `tfsdk:"files"`
+	Id         types.String `tfsdk:"id"`
+	Mode       types.String `tfsdk:"mode"`
+	Paths      types.List   `tfsdk:"paths"`
+	SourceId   types.String `tfsdk:"source_id"`
+	Username   types.String `tfsdk:"username"`
+}
+
+// tilesetSource is a tileset source as returned by the Mapbox Tiling Service.
+type tilesetSource struct {
+	Id         string `json:"id"`
+	Files      int64  `json:"files"`
+	Size       int64  `json:"size"`
+	SourceSize int64  `json:"source_size"`
+}
+
+func (r *TilesetSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tileset_source"
+}
+
+func (r *TilesetSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads files as a tileset source of the Mapbox Tiling Service. GeoJSON FeatureCollections, CSV files and zipped Shapefiles are converted to line-delimited GeoJSON while they are streamed from disk. The geometries of changed files are validated during plan, and files are only uploaded again when their content changes.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the tileset source.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the tileset source, up to 32 characters of letters, numbers, `-` and `_`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"paths": schema.ListAttribute{
+				MarkdownDescription: "The paths of the files to upload, at most 10. The format is detected from the extension: `.geojson` and `.json` files are FeatureCollections, `.csv` files have a latitude (`lat`, `latitude` or `y`) and longitude (`lon`, `lng`, `long`, `longitude` or `x`) column with the other columns becoming string properties, `.zip` files contain a Shapefile in WGS 84 coordinates and all other files are line-delimited GeoJSON.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "How changed files are uploaded. `replace` replaces the whole source with the configured files, `append` appends the changed files to the data already in the source. Defaults to `replace`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(tilesetSourceModeReplace),
+			},
+			"file_hashes": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The SHA-256 hashes of the uploaded files, keyed by path.",
+			},
+			"file_size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The total size of the tileset source in bytes.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"files": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of files in the tileset source.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The `mapbox://tileset-source/` URL of the source, as referenced by tileset recipes.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TilesetSourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TilesetSourceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TilesetSourceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if mode := data.Mode.ValueString(); !data.Mode.IsNull() && !data.Mode.IsUnknown() && mode != tilesetSourceModeReplace && mode != tilesetSourceModeAppend {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mode"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("mode must be %q or %q, got: %q", tilesetSourceModeReplace, tilesetSourceModeAppend, mode),
+		)
+	}
+
+	if !data.Paths.IsNull() && !data.Paths.IsUnknown() {
+		if n := len(data.Paths.Elements()); n == 0 || n > 10 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("paths"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("A tileset source takes between 1 and 10 files, got: %d", n),
+			)
+		}
+	}
+}
+
+// ModifyPlan hashes the files during plan, so changed files show up as an update.
+func (r *TilesetSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data TilesetSourceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Paths.IsUnknown() {
+		return
+	}
+
+	for _, p := range data.Paths.Elements() {
+		if p.IsUnknown() {
+			return
+		}
+	}
+
+	hashes, diags := data.hashFiles(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_hashes"), hashes)...)
+
+	var state TilesetSourceResourceModel
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	resp.Diagnostics.Append(data.validateFiles(ctx, hashes, state.FileHashes)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if !state.FileHashes.Equal(hashes) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_size"), types.Int64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("files"), types.Int64Unknown())...)
+	}
+}
+
+func (r *TilesetSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TilesetSourceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	var paths []string
+	resp.Diagnostics.Append(data.Paths.ElementsAs(ctx, &paths, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Creating appends every file, there is nothing to replace yet.
+	if err := r.upload(ctx, &data, paths, false); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload tileset source, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a tileset source", map[string]any{"id": data.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TilesetSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TilesetSourceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, err := getTilesetSource(r.client, data.Username.ValueString(), data.SourceId.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "tileset source not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tileset source, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(source.Id)
+	data.FileSize = types.Int64Value(source.Size)
+	data.Files = types.Int64Value(source.Files)
+
+	if data.Mode.IsNull() {
+		data.Mode = types.StringValue(tilesetSourceModeReplace)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TilesetSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state TilesetSourceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.FileHashes.Equal(state.FileHashes) {
+		data.FileSize = state.FileSize
+		data.Files = state.Files
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	var paths []string
+	planned := map[string]string{}
+	prior := map[string]string{}
+	resp.Diagnostics.Append(data.Paths.ElementsAs(ctx, &paths, false)...)
+	resp.Diagnostics.Append(data.FileHashes.ElementsAs(ctx, &planned, false)...)
+	if !state.FileHashes.IsNull() {
+		resp.Diagnostics.Append(state.FileHashes.ElementsAs(ctx, &prior, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replace := data.Mode.ValueString() != tilesetSourceModeAppend
+	if !replace {
+		var changed []string
+		for _, p := range paths {
+			if prior[p] != planned[p] {
+				changed = append(changed, p)
+			}
+		}
+		paths = changed
+	}
+
+	if err := r.upload(ctx, &data, paths, replace); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload tileset source, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TilesetSourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TilesetSourceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.Delete(tilesetSourceEndpoint(data.Username.ValueString(), data.SourceId.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete tileset source, got error: %s", err))
+		return
+	}
+
+	_ = httpResp.Body.Close()
+}
+
+func (r *TilesetSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("unexpected format of ID (%q), expected USERNAME/SOURCE-ID", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), parts[1])...)
+}
+
+// upload sends the files one after another. With replace the first file replaces the data
+// of the source and the others are appended to it, otherwise every file is appended.
+func (r *TilesetSourceResource) upload(ctx context.Context, data *TilesetSourceResourceModel, paths []string, replace bool) error {
+	endpoint := tilesetSourceEndpoint(data.Username.ValueString(), data.SourceId.ValueString())
+
+	var source tilesetSource
+	for i, p := range paths {
+		method := http.MethodPost
+		if replace && i == 0 {
+			method = http.MethodPut
+		}
+
+		tflog.Debug(ctx, "uploading tileset source file", map[string]any{"path": p, "method": method})
+
+		if err := uploadConvertedTilesetSourceFile(r.client, method, endpoint, p, &source); err != nil {
+			return err
+		}
+	}
+
+	if len(paths) == 0 {
+		current, err := getTilesetSource(r.client, data.Username.ValueString(), data.SourceId.ValueString())
+		if err != nil {
+			return err
+		}
+
+		source = current
+		source.SourceSize = current.Size
+	}
+
+	data.Id = types.StringValue(source.Id)
+	data.FileSize = types.Int64Value(source.SourceSize)
+	data.Files = types.Int64Value(source.Files)
+
+	return nil
+}
+
+func getTilesetSource(client *Client, userName, id string) (tilesetSource, error) {
+	var source tilesetSource
+
+	resp, err := client.Get(tilesetSourceEndpoint(userName, id))
+	if err != nil {
+		return source, err
+	}
+
+	err = decodeJSON(resp, &source)
+	return source, err
+}
+
+func uploadTilesetSourceFile(client *Client, method, endpoint, p string, source *tilesetSource) error {
+	file, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return uploadTilesetSourceContent(client, method, endpoint, filepath.Base(p), file, source)
+}
+
+// uploadConvertedTilesetSourceFile converts the file to line-delimited GeoJSON while it is
+// uploaded. Invalid features fail the upload, they are reported during plan already.
+func uploadConvertedTilesetSourceFile(client *Client, method, endpoint, p string, source *tilesetSource) error {
+	reader, writer := io.Pipe()
+	converted := make(chan error, 1)
+
+	go func() {
+		invalid, err := convertSourceFile(p, writer)
+		if err == nil && len(invalid) > 0 {
+			err = fmt.Errorf("%d invalid features, the first at %s", len(invalid), invalid[0])
+		}
+		_ = writer.CloseWithError(err)
+		converted <- err
+	}()
+
+	err := uploadTilesetSourceContent(client, method, endpoint, lineDelimitedName(p), reader, source)
+
+	// Unblocks the conversion when the upload finished before all of it was read.
+	_ = reader.Close()
+
+	if convertErr := <-converted; err == nil && convertErr != nil && !errors.Is(convertErr, io.ErrClosedPipe) {
+		return fmt.Errorf("%s: %w", p, convertErr)
+	}
+
+	return err
+}
+
+func uploadTilesetSourceContent(client *Client, method, endpoint, fileName string, content io.Reader, source *tilesetSource) error {
+	resp, err := client.DoMultipart(method, endpoint, fileName, content)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	return decodeJSON(resp, source)
+}
+
+func (data TilesetSourceResourceModel) hashFiles(ctx context.Context) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var paths []string
+	diags.Append(data.Paths.ElementsAs(ctx, &paths, false)...)
+
+	hashes := make(map[string]string, len(paths))
+	for _, p := range paths {
+		hash, err := hashFile(p)
+		if err != nil {
+			diags.AddAttributeError(path.Root("paths"), "Unable to Read File", err.Error())
+			continue
+		}
+
+		hashes[p] = hash
+	}
+
+	if diags.HasError() {
+		return types.MapNull(types.StringType), diags
+	}
+
+	value, d := types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(d...)
+
+	return value, diags
+}
+
+// validateFiles converts the files whose hashes changed from prior without uploading them,
+// reporting the files that cannot be converted and their invalid features.
+func (data TilesetSourceResourceModel) validateFiles(ctx context.Context, hashes, prior types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var paths []string
+	diags.Append(data.Paths.ElementsAs(ctx, &paths, false)...)
+
+	previous := prior.Elements()
+
+	for i, p := range paths {
+		if hash, ok := hashes.Elements()[p]; ok && hash.Equal(previous[p]) {
+			continue
+		}
+
+		filePath := path.Root("paths").AtListIndex(i)
+
+		invalid, err := convertSourceFile(p, io.Discard)
+		if err != nil {
+			diags.AddAttributeError(filePath, "Unable to Convert File", fmt.Sprintf("Unable to convert %s from %s to line-delimited GeoJSON: %s", p, detectSourceFormat(p), err))
+			continue
+		}
+
+		for j, featureErr := range invalid {
+			if j == maxFeatureErrors {
+				diags.AddAttributeError(filePath, "Invalid Features", fmt.Sprintf("%s has %d more invalid features.", p, len(invalid)-maxFeatureErrors))
+				break
+			}
+
+			diags.AddAttributeError(filePath, "Invalid Feature", fmt.Sprintf("%s, %s", p, featureErr))
+		}
+	}
+
+	return diags
+}
+
+// hashFile returns the SHA-256 hash of a file without reading it into memory at once.
+func hashFile(p string) (string, error) {
+	file, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func tilesetSourceEndpoint(userName, id string) string {
+	return fmt.Sprintf("tilesets/v1/sources/%s/%s", userName, id)
+}
@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccTilesetSourceResource_basic(t *testing.T) {
+	resourceName := "mapbox_tileset_source.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	sourceId := "tf-acc-test-source"
+
+	dir := t.TempDir()
+	geojsonPath := filepath.Join(dir, "points.geojsonld")
+	writeFeatures := func(features ...string) {
+		if err := os.WriteFile(geojsonPath, []byte(strings.Join(features, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFeatures(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [13.4, 52.5]}, "properties": {"name": "Berlin"}}`)
+
+	if os.Getenv("MOCK") != "" {
+		endpoint := fmt.Sprintf("tilesets/v1/sources/%s/%s", username, sourceId)
+		id := fmt.Sprintf("mapbox://tileset-source/%s/%s", username, sourceId)
+
+		var size int
+		upload := func(res *http.Response) *http.Response {
+			body, _ := io.ReadAll(res.Request.Body)
+			if !strings.Contains(string(body), `"type": "Feature"`) {
+				res.StatusCode = http.StatusBadRequest
+			}
+
+			size = len(body)
+			current := fmt.Sprintf(`{"id": %q, "files": 1, "source_size": %d, "file_size": %d}`, id, size, size)
+			return mockBody(&current)(res)
+		}
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Post(endpoint).
+			MatchHeader("Content-Type", "^multipart/form-data").
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusOK).
+			Map(upload)
+
+		gock.New("https://api.mapbox.com").
+			Put(endpoint).
+			MatchHeader("Content-Type", "^multipart/form-data").
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusOK).
+			Map(upload)
+
+		gock.New("https://api.mapbox.com").
+			Get(endpoint).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				current := fmt.Sprintf(`{"id": %q, "files": 1, "size": %d}`, id, size)
+				return mockBody(&current)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Delete(endpoint).
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusNoContent)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTilesetSourceResourceConfig(username, sourceId, geojsonPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("mapbox://tileset-source/%s/%s", username, sourceId)),
+					resource.TestCheckResourceAttr(resourceName, "mode", "replace"),
+					resource.TestCheckResourceAttr(resourceName, "files", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "file_size"),
+					resource.TestCheckResourceAttrSet(resourceName, "file_hashes."+geojsonPath),
+				),
+			},
+			// Unchanged files are not uploaded again
+			{
+				Config:   testAccTilesetSourceResourceConfig(username, sourceId, geojsonPath),
+				PlanOnly: true,
+			},
+			// Changed files replace the source
+			{
+				PreConfig: func() {
+					writeFeatures(
+						`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [13.4, 52.5]}, "properties": {"name": "Berlin"}}`,
+						`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [2.35, 48.86]}, "properties": {"name": "Paris"}}`,
+					)
+				},
+				Config: testAccTilesetSourceResourceConfig(username, sourceId, geojsonPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "files", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTilesetSourceResource_csv(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("converted uploads are only tested against mocks")
+	}
+
+	resourceName := "mapbox_tileset_source.test"
+	username := "test"
+	sourceId := "tf-acc-test-csv"
+	endpoint := fmt.Sprintf("tilesets/v1/sources/%s/%s", username, sourceId)
+	id := fmt.Sprintf("mapbox://tileset-source/%s/%s", username, sourceId)
+
+	csvPath := filepath.Join(t.TempDir(), "cities.csv")
+	if err := os.WriteFile(csvPath, []byte("name,lat,lon\nBerlin,52.5,13.4\nParis,48.86,2.35\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Post(endpoint).
+		MatchHeader("Content-Type", "^multipart/form-data").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			body, _ := io.ReadAll(res.Request.Body)
+			if !strings.Contains(string(body), `filename="cities.geojsonld"`) || strings.Count(string(body), `"type":"Feature"`) != 2 {
+				res.StatusCode = http.StatusBadRequest
+			}
+
+			current := fmt.Sprintf(`{"id": %q, "files": 1, "source_size": %d, "file_size": %d}`, id, len(body), len(body))
+			return mockBody(&current)(res)
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get(endpoint).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]any{"id": id, "files": 1, "size": 256})
+
+	gock.New("https://api.mapbox.com").
+		Delete(endpoint).
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusNoContent)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTilesetSourceResourceConfig(username, sourceId, csvPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "files", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTilesetSourceResource_invalidFeatures(t *testing.T) {
+	geojsonPath := filepath.Join(t.TempDir(), "points.geojson")
+	content := `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Point", "coordinates": [13.4, 152.5]}, "properties": {}}]}`
+	if err := os.WriteFile(geojsonPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTilesetSourceResourceConfig("test", "tf-acc-test-invalid", geojsonPath),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`feature 1: invalid Point`),
+			},
+		},
+	})
+}
+
+func testAccTilesetSourceResourceConfig(username, sourceId, geojsonPath string) string {
+	return fmt.Sprintf(`
+resource "mapbox_tileset_source" "test" {
+  username  = %[1]q
+  source_id = %[2]q
+  paths     = [%[3]q]
+}
+`, username, sourceId, geojsonPath)
+}
@@ -0,0 +1,692 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccTilesetResource_basic(t *testing.T) {
+	resourceName := "mapbox_tileset.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	tilesetId := username + ".tf-acc-test"
+
+	if os.Getenv("MOCK") != "" {
+		endpoint := fmt.Sprintf("tilesets/v1/%s", tilesetId)
+		recipe := `{"version": 1, "layers": {"points": {"source": "mapbox://tileset-source/test/points", "minzoom": 0, "maxzoom": 5}}}`
+
+		defer func(interval time.Duration) { tilesetJobPollInterval = interval }(tilesetJobPollInterval)
+		tilesetJobPollInterval = time.Millisecond
+
+		var jobs int
+		var polls int
+		var info string
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Put("tilesets/v1/validateRecipe").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			JSON(map[string]any{"valid": true})
+
+		// The more specific mocks go first, the tileset mocks would match their paths too.
+		gock.New("https://api.mapbox.com").
+			Post(endpoint+"/publish").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				jobs++
+				polls = 0
+				current := fmt.Sprintf(`{"message": "Processing %s", "jobId": "job-%d"}`, tilesetId, jobs)
+				return mockBody(&current)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Get(endpoint+"/jobs/").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				stage := "processing"
+				if polls++; polls > 1 {
+					stage = "success"
+				}
+				current := fmt.Sprintf(`{"id": "job-%d", "stage": %q, "errors": [], "warnings": []}`, jobs, stage)
+				return mockBody(&current)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Get(endpoint+"/recipe").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			Map(func(res *http.Response) *http.Response {
+				current := fmt.Sprintf(`{"id": %q, "recipe": %s}`, tilesetId, recipe)
+				return mockBody(&current)(res)
+			})
+
+		gock.New("https://api.mapbox.com").
+			Patch(endpoint+"/recipe").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusNoContent).
+			Map(mockSaveBody(&recipe))
+
+		gock.New("https://api.mapbox.com").
+			Post(endpoint).
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusOK).
+			Map(mockSaveBody(&info))
+
+		gock.New("https://api.mapbox.com").
+			Patch(endpoint).
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusNoContent).
+			Map(mockSaveBody(&info))
+
+		mockTilesetList(tilesetId, &info)
+
+		gock.New("https://api.mapbox.com").
+			Delete(endpoint).
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusNoContent)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTilesetResourceConfig(tilesetId, "Points", 5, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", tilesetId),
+					resource.TestCheckResourceAttr(resourceName, "private", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "job_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"job_id", "publish_mode", "publish_triggers", "timeouts"},
+			},
+			// Renaming does not publish the tileset again
+			{
+				Config: testAccTilesetResourceConfig(tilesetId, "Renamed points", 5, "1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("job_id"), knownvalue.NotNull()),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "name", "Renamed points"),
+			},
+			// Recipe changes publish the tileset
+			{
+				Config: testAccTilesetResourceConfig(tilesetId, "Renamed points", 8, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "recipe", regexp.MustCompile(`"maxzoom":8`)),
+					resource.TestCheckResourceAttrSet(resourceName, "job_id"),
+				),
+			},
+			// Changed triggers publish the tileset
+			{
+				Config: testAccTilesetResourceConfig(tilesetId, "Renamed points", 8, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "publish_triggers.source", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "job_id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTilesetResource_jobFailure(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("failed publish jobs can only be tested against mocks")
+	}
+
+	tilesetId := "test.tf-acc-test-failure"
+	endpoint := fmt.Sprintf("tilesets/v1/%s", tilesetId)
+
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Post(endpoint+"/publish").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{"jobId": "job-1"})
+
+	gock.New("https://api.mapbox.com").
+		Get(endpoint+"/jobs/job-1").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{"id": "job-1", "stage": "failed", "errors": []string{"source mapbox://tileset-source/test/points does not exist"}})
+
+	gock.New("https://api.mapbox.com").
+		Get(endpoint+"/recipe").
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]any{"id": tilesetId, "recipe": json.RawMessage(`{"version": 1, "layers": {}}`)})
+
+	gock.New("https://api.mapbox.com").
+		Post(endpoint).
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{})
+
+	info := `{"name": "Points", "private": true}`
+	mockTilesetList(tilesetId, &info)
+
+	gock.New("https://api.mapbox.com").
+		Delete(endpoint).
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusNoContent)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTilesetResourceConfig(tilesetId, "Points", 5, "1"),
+				ExpectError: regexp.MustCompile(`does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccTilesetResource_rejectedRecipe(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("rejected recipes are only tested against mocks")
+	}
+
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Put("tilesets/v1/validateRecipe").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{"valid": false, "errors": []string{"layers.points.features.attributes: unknown key \"allowed\""}})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTilesetResourceConfig("test.tf-acc-test-rejected", "Points", 5, "1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`unknown key "allowed"`),
+			},
+		},
+	})
+}
+
+func TestAccTilesetResource_invalidRecipe(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mapbox_tileset" "test" {
+  tileset_id = "test.invalid"
+  name       = "Invalid"
+  recipe = jsonencode({
+    version = 1
+    layers = {
+      points = { source = "mapbox://tileset-source/test/points", minzoom = 10, maxzoom = 6 }
+    }
+  })
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`layer "points": minzoom must not be greater than maxzoom`),
+			},
+		},
+	})
+}
+
+func TestAccTilesetResource_layers(t *testing.T) {
+	resourceName := "mapbox_tileset.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+	tilesetId := username + ".tf-acc-test-layers"
+	recipe := fmt.Sprintf(`{"layers":{"points":{"features":{"attributes":{"allowed_output":["name"]}},"maxzoom":12,"minzoom":0,"source":"mapbox://tileset-source/%s/points","tiles":{"layer_size":2500}}},"version":1}`, username)
+
+	if os.Getenv("MOCK") != "" {
+		endpoint := fmt.Sprintf("tilesets/v1/%s", tilesetId)
+
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Put("tilesets/v1/validateRecipe").
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"valid": true})
+
+		gock.New("https://api.mapbox.com").
+			Post(endpoint+"/publish").
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"jobId": "job-1"})
+
+		gock.New("https://api.mapbox.com").
+			Get(endpoint+"/jobs/job-1").
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"id": "job-1", "stage": "success"})
+
+		gock.New("https://api.mapbox.com").
+			Get(endpoint+"/recipe").
+			MatchParam("access_token", "test-token").
+			Persist().
+			Reply(http.StatusOK).
+			JSON(map[string]any{"id": tilesetId, "recipe": json.RawMessage(recipe)})
+
+		gock.New("https://api.mapbox.com").
+			Post(endpoint).
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusOK).
+			JSON(map[string]any{})
+
+		info := `{"name": "Points", "private": true}`
+		mockTilesetList(tilesetId, &info)
+
+		gock.New("https://api.mapbox.com").
+			Delete(endpoint).
+			MatchParam("access_token", "test-token").
+			Reply(http.StatusNoContent)
+	}
+
+	config := fmt.Sprintf(`
+resource "mapbox_tileset" "test" {
+  tileset_id = %[1]q
+  name       = "Points"
+
+  layer {
+    name    = "points"
+    source  = "mapbox://tileset-source/%[2]s/points"
+    minzoom = 0
+    maxzoom = 12
+
+    features {
+      attributes {
+        allowed_output = ["name"]
+      }
+    }
+
+    tiles {
+      layer_size = 2500
+    }
+  }
+}
+`, tilesetId, username)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The layer blocks are compiled into the recipe
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "recipe", recipe),
+					resource.TestCheckResourceAttr(resourceName, "layer.0.name", "points"),
+				),
+			},
+			// Unchanged layer blocks do not produce a diff
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTilesetResource_recipeConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mapbox_tileset" "test" {
+  tileset_id = "test.conflict"
+  name       = "Conflict"
+  recipe     = jsonencode({ version = 1, layers = {} })
+
+  layer {
+    name    = "points"
+    source  = "mapbox://tileset-source/test/points"
+    minzoom = 0
+    maxzoom = 12
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Only one of `recipe` and `layer` blocks can be set"),
+			},
+			{
+				Config: `
+resource "mapbox_tileset" "test" {
+  tileset_id = "test.missing"
+  name       = "Missing"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("One of `recipe` and `layer` blocks must be set"),
+			},
+		},
+	})
+}
+
+func TestAccTilesetResource_incremental(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("incremental updates are only tested against mocks")
+	}
+
+	resourceName := "mapbox_tileset.test"
+	tilesetId := "test.tf-acc-test-incremental"
+	endpoint := fmt.Sprintf("tilesets/v1/%s", tilesetId)
+	recipe := `{"version": 1, "layers": {"points": {"source": "mapbox://tileset-source/test/points", "minzoom": 0, "maxzoom": 5}}}`
+	changesetPath := filepath.Join(t.TempDir(), "points.geojsonld")
+
+	writeChangeset := func(name string) {
+		feature := fmt.Sprintf(`{"type": "Feature", "id": 1, "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {"name": %q}}`, name)
+		if err := os.WriteFile(changesetPath, []byte(feature+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeChangeset("Null Island")
+
+	defer func(interval time.Duration) { tilesetJobPollInterval = interval }(tilesetJobPollInterval)
+	tilesetJobPollInterval = time.Millisecond
+
+	var published string
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Put("tilesets/v1/validateRecipe").
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]any{"valid": true})
+
+	gock.New("https://api.mapbox.com").
+		Post("tilesets/v1/changesets/test/tf-acc-test-incremental-points").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{"id": "mapbox://tileset-changeset/test/tf-acc-test-incremental-points", "files": 1})
+
+	// The changeset publish mock goes first, the publish mock would match its path too.
+	gock.New("https://api.mapbox.com").
+		Post(endpoint+"/publish-changesets").
+		MatchParam("access_token", "test-token").
+		BodyString(`"changeset":"mapbox://tileset-changeset/test/tf-acc-test-incremental-points"`).
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			published = "job-2"
+			return res
+		}).
+		JSON(map[string]any{"jobId": "job-2"})
+
+	gock.New("https://api.mapbox.com").
+		Post(endpoint+"/publish").
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{"jobId": "job-1"})
+
+	gock.New("https://api.mapbox.com").
+		Get(endpoint+"/jobs/").
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			job := "job-1"
+			if published != "" {
+				job = published
+			}
+			current := fmt.Sprintf(`{"id": %q, "stage": "success"}`, job)
+			return mockBody(&current)(res)
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get(endpoint+"/recipe").
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]any{"id": tilesetId, "recipe": json.RawMessage(recipe)})
+
+	gock.New("https://api.mapbox.com").
+		Post(endpoint).
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusOK).
+		JSON(map[string]any{})
+
+	info := `{"name": "Points", "private": true}`
+	mockTilesetList(tilesetId, &info)
+
+	gock.New("https://api.mapbox.com").
+		Delete(endpoint).
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusNoContent)
+
+	config := fmt.Sprintf(`
+resource "mapbox_tileset" "test" {
+  tileset_id  = %[1]q
+  name        = "Points"
+  update_mode = "incremental"
+  recipe      = %[2]q
+
+  changesets = {
+    points = %[3]q
+  }
+}
+`, tilesetId, recipe, changesetPath)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Creating publishes the whole tileset
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "publish_mode", "full"),
+					resource.TestCheckResourceAttr(resourceName, "job_id", "job-1"),
+					resource.TestCheckResourceAttrSet(resourceName, "changeset_hashes.points"),
+				),
+			},
+			// A changed changeset is published incrementally
+			{
+				PreConfig: func() { writeChangeset("Null Island, renamed") },
+				Config:    config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("publish_mode"), knownvalue.StringExact("incremental")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "publish_mode", "incremental"),
+					resource.TestCheckResourceAttr(resourceName, "job_id", "job-2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTilesetResource_changesetsRequireIncremental(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mapbox_tileset" "test" {
+  tileset_id = "test.tf-acc-test-changesets"
+  name       = "Points"
+  recipe     = jsonencode({ version = 1, layers = { points = { source = "mapbox://tileset-source/test/points", minzoom = 0, maxzoom = 5 } } })
+
+  changesets = {
+    points = "points.geojsonld"
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Changesets Require Incremental Updates"),
+			},
+		},
+	})
+}
+
+// mockTilesetList mocks the tileset list of the owner of the tileset, with the name, description
+// and visibility last sent in info.
+func mockTilesetList(tilesetId string, info *string) {
+	username, _, _ := strings.Cut(tilesetId, ".")
+
+	gock.New("https://api.mapbox.com").
+		Get(fmt.Sprintf("tilesets/v1/%s$", username)).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			var fields struct {
+				Name        string `json:"name"`
+				Description string `json:"description"`
+				Private     bool   `json:"private"`
+			}
+			_ = json.Unmarshal([]byte(*info), &fields)
+
+			visibility := "public"
+			if fields.Private {
+				visibility = "private"
+			}
+
+			list, _ := json.Marshal([]tilesetSummary{{Id: tilesetId, Name: fields.Name, Description: fields.Description, Type: "vector", Visibility: visibility}})
+			current := string(list)
+			return mockBody(&current)(res)
+		})
+}
+
+func TestTilesetChangesetId(t *testing.T) {
+	cases := map[[2]string]string{
+		{"roads", "highways"}:       "roads-highways",
+		{"roads", "major roads.v2"}: "roads-major-roads-v2",
+	}
+
+	for args, expected := range cases {
+		if id := tilesetChangesetId(args[0], args[1]); id != expected {
+			t.Errorf("tilesetChangesetId(%q, %q) = %q, expected %q", args[0], args[1], id, expected)
+		}
+	}
+
+	// Long IDs that only differ after the first 32 characters keep distinct changesets.
+	first := tilesetChangesetId("a-very-long-tileset-name", "buildings-residential")
+	second := tilesetChangesetId("a-very-long-tileset-name", "buildings-commercial")
+
+	for _, id := range []string{first, second} {
+		if len(id) != 32 || !strings.HasPrefix(id, "a-very-long-tileset-") {
+			t.Errorf("tilesetChangesetId() = %q, expected 32 characters starting with the tileset name", id)
+		}
+	}
+	if first == second {
+		t.Errorf("tilesetChangesetId() = %q for both layers, expected distinct IDs", first)
+	}
+	if again := tilesetChangesetId("a-very-long-tileset-name", "buildings-residential"); again != first {
+		t.Errorf("tilesetChangesetId() = %q, then %q, expected a stable ID", first, again)
+	}
+}
+
+func TestValidateRecipe(t *testing.T) {
+	defer gock.OffAll()
+
+	token := "test-token"
+	client := &Client{AccessToken: &token, HTTPClient: &http.Client{}}
+	recipe := `{"version": 1, "layers": {}}`
+
+	cases := []struct {
+		status  int
+		summary string
+		isError bool
+	}{
+		{http.StatusBadRequest, "Invalid Recipe", true},
+		{http.StatusUnprocessableEntity, "Invalid Recipe", true},
+		{http.StatusUnauthorized, "Client Error", true},
+		{http.StatusForbidden, "Client Error", true},
+		{http.StatusTooManyRequests, "Recipe Not Validated", false},
+		{http.StatusBadGateway, "Recipe Not Validated", false},
+	}
+
+	for _, c := range cases {
+		gock.New("https://api.mapbox.com").
+			Put("tilesets/v1/validateRecipe").
+			MatchParam("access_token", "test-token").
+			Reply(c.status).
+			JSON(map[string]any{"message": http.StatusText(c.status)})
+
+		diags := validateRecipe(context.Background(), client, recipe, path.Root("recipe"))
+
+		if len(diags) != 1 || diags[0].Summary() != c.summary || diags.HasError() != c.isError {
+			t.Errorf("status %d: got diagnostics %v, expected a single %q", c.status, diags, c.summary)
+		}
+	}
+}
+
+func TestJobMessage(t *testing.T) {
+	cases := map[string]string{
+		`"source is empty"`:            "source is empty",
+		`{"message": "invalid layer"}`: `{"message": "invalid layer"}`,
+	}
+
+	for raw, expected := range cases {
+		if message := jobMessage(json.RawMessage(raw)); message != expected {
+			t.Errorf("jobMessage(%s) = %q, expected %q", raw, message, expected)
+		}
+	}
+}
+
+func testAccTilesetResourceConfig(tilesetId, name string, maxzoom int, trigger string) string {
+	return fmt.Sprintf(`
+resource "mapbox_tileset" "test" {
+  tileset_id = %[1]q
+  name       = %[2]q
+  recipe = jsonencode({
+    version = 1
+    layers = {
+      points = {
+        source  = "mapbox://tileset-source/test/points"
+        minzoom = 0
+        maxzoom = %[3]d
+      }
+    }
+  })
+
+  publish_triggers = {
+    source = %[4]q
+  }
+}
+`, tilesetId, name, maxzoom, trigger)
+}
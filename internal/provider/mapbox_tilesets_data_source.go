@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TilesetsDataSource{}
+
+var (
+	tilesetTypes        = []string{"vector", "raster", "rasterarray"}
+	tilesetVisibilities = []string{"public", "private"}
+	tilesetSortFields   = []string{"created", "modified"}
+)
+
+func NewTilesetsDataSource() datasource.DataSource {
+	return &TilesetsDataSource{}
+}
+
+// TilesetsDataSource defines the data source implementation.
+type TilesetsDataSource struct {
+	client *Client
+}
+
+// TilesetsDataSourceModel describes the data source data model.
+type TilesetsDataSourceModel struct {
+	Id         types.String          `tfsdk:"id"`
+	SortBy     types.String          `tfsdk:"sort_by"`
+	Tilesets   []TilesetSummaryModel `tfsdk:"tilesets"`
+	Type       types.String          `tfsdk:"type"`
+	Username   types.String          `tfsdk:"username"`
+	Visibility types.String          `tfsdk:"visibility"`
+}
+
+// TilesetSummaryModel describes a tileset in the list of the data source.
+type TilesetSummaryModel struct {
+	Created     types.String `tfsdk:"created"`
+	Description types.String `tfsdk:"description"`
+	Filesize    types.Int64  `tfsdk:"filesize"`
+	Id          types.String `tfsdk:"id"`
+	Modified    types.String `tfsdk:"modified"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Visibility  types.String `tfsdk:"visibility"`
+}
+
+// tilesetSummary is a tileset as returned by the list endpoint of the Tilesets API.
+type tilesetSummary struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Visibility  string `json:"visibility"`
+	Filesize    int64  `json:"filesize"`
+	Created     string `json:"created"`
+	Modified    string `json:"modified"`
+}
+
+func (d *TilesetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tilesets"
+}
+
+func (d *TilesetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the tilesets of an account, optionally filtered by type and visibility.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account to list the tilesets of.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list tilesets of this type, one of `vector`, `raster` or `rasterarray`.",
+				Optional:            true,
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Only list tilesets with this visibility, `public` or `private`.",
+				Optional:            true,
+			},
+			"sort_by": schema.StringAttribute{
+				MarkdownDescription: "Sort the tilesets by `created` or `modified` date, newest first.",
+				Optional:            true,
+			},
+			"tilesets": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching tilesets.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the tileset.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the tileset.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The description of the tileset.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the tileset, `vector`, `raster` or `rasterarray`.",
+						},
+						"visibility": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the tileset is `public` or `private`.",
+						},
+						"filesize": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The storage size of the tileset in bytes.",
+						},
+						"created": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The date and time the tileset was created.",
+						},
+						"modified": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The date and time the tileset was last modified.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username the tilesets were listed for.",
+			},
+		},
+	}
+}
+
+func (d *TilesetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TilesetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TilesetsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("limit", "500")
+
+	filters := []struct {
+		attribute string
+		param     string
+		value     types.String
+		allowed   []string
+	}{
+		{"type", "type", data.Type, tilesetTypes},
+		{"visibility", "visibility", data.Visibility, tilesetVisibilities},
+		{"sort_by", "sortby", data.SortBy, tilesetSortFields},
+	}
+
+	for _, filter := range filters {
+		if filter.value.IsNull() {
+			continue
+		}
+
+		if !slices.Contains(filter.allowed, filter.value.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root(filter.attribute), "Invalid Filter", fmt.Sprintf("The %s must be one of %s, got: %s", filter.attribute, strings.Join(filter.allowed, ", "), filter.value.ValueString()))
+			continue
+		}
+
+		query.Set(filter.param, filter.value.ValueString())
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Tilesets = []TilesetSummaryModel{}
+
+	err := d.client.ListPages(fmt.Sprintf("tilesets/v1/%s?%s", data.Username.ValueString(), query.Encode()), func(body []byte) error {
+		var page []tilesetSummary
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decode tilesets: %w", err)
+		}
+
+		for _, tileset := range page {
+			data.Tilesets = append(data.Tilesets, TilesetSummaryModel{
+				Created:     types.StringValue(tileset.Created),
+				Description: types.StringValue(tileset.Description),
+				Filesize:    types.Int64Value(tileset.Filesize),
+				Id:          types.StringValue(tileset.Id),
+				Modified:    types.StringValue(tileset.Modified),
+				Name:        types.StringValue(tileset.Name),
+				Type:        types.StringValue(tileset.Type),
+				Visibility:  types.StringValue(tileset.Visibility),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list tilesets, got error: %s", err))
+		return
+	}
+
+	data.Id = data.Username
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
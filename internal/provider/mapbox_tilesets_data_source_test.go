@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccTilesetsDataSource_basic(t *testing.T) {
+	dataSourceName := "data.mapbox_tilesets.test"
+	username := os.Getenv("MAPBOX_USERNAME")
+
+	if os.Getenv("MOCK") != "" {
+		defer gock.OffAll()
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("tilesets/v1/%s", username)).
+			MatchParam("access_token", "test-token").
+			MatchParam("type", "vector").
+			MatchParam("visibility", "private").
+			MatchParam("sortby", "modified").
+			MatchParam("start", "page2").
+			Persist().
+			Reply(http.StatusOK).
+			BodyString(fmt.Sprintf(`[
+  {"type": "vector", "id": "%[1]s.parcels", "name": "Parcels", "description": "", "visibility": "private", "filesize": 1024, "created": "2024-01-01T10:00:00.000Z", "modified": "2024-01-02T10:00:00.000Z"}
+]`, username))
+
+		gock.New("https://api.mapbox.com").
+			Get(fmt.Sprintf("tilesets/v1/%s", username)).
+			MatchParam("access_token", "test-token").
+			MatchParam("type", "vector").
+			MatchParam("visibility", "private").
+			MatchParam("sortby", "modified").
+			MatchParam("limit", "500").
+			Persist().
+			Reply(http.StatusOK).
+			SetHeader("Link", fmt.Sprintf(`<https://api.mapbox.com/tilesets/v1/%s?limit=500&sortby=modified&type=vector&visibility=private&start=page2&access_token=test-token>; rel="next"`, username)).
+			BodyString(fmt.Sprintf(`[
+  {"type": "vector", "id": "%[1]s.stores", "name": "Stores", "description": "Store locations", "visibility": "private", "filesize": 52428800, "created": "2024-02-01T10:00:00.000Z", "modified": "2024-03-01T10:00:00.000Z"}
+]`, username))
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "mapbox_tilesets" "test" {
+  username   = %q
+  type       = "vector"
+  visibility = "private"
+  sort_by    = "modified"
+}
+`, username),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "tilesets.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "tilesets.0.id", username+".stores"),
+					resource.TestCheckResourceAttr(dataSourceName, "tilesets.0.filesize", "52428800"),
+					resource.TestCheckResourceAttr(dataSourceName, "tilesets.0.modified", "2024-03-01T10:00:00.000Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "tilesets.1.name", "Parcels"),
+					resource.TestCheckResourceAttr(dataSourceName, "tilesets.1.type", "vector"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTilesetsDataSource_invalidFilter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "mapbox_tilesets" "test" {
+  username = "test"
+  type     = "mbtiles"
+}
+`,
+				ExpectError: regexp.MustCompile(`The type must be one of vector, raster, rasterarray, got: mbtiles`),
+			},
+		},
+	})
+}
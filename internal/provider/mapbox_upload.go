@@ -0,0 +1,458 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UploadResource{}
+var _ resource.ResourceWithImportState = &UploadResource{}
+var _ resource.ResourceWithModifyPlan = &UploadResource{}
+var _ resource.ResourceWithValidateConfig = &UploadResource{}
+
+// uploadPollInterval is how often the progress of an upload is checked.
+var uploadPollInterval = 5 * time.Second
+
+func NewUploadResource() resource.Resource {
+	return &UploadResource{}
+}
+
+// UploadResource defines the resource implementation.
+type UploadResource struct {
+	client *Client
+}
+
+// UploadResourceModel describes the resource data model.
+type UploadResourceModel struct {
+	FileHash  types.String   `tfsdk:"file_hash"`
+	Id        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	Path      types.String   `tfsdk:"path"`
+	TilesetId types.String   `tfsdk:"tileset_id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Username  types.String   `tfsdk:"username"`
+}
+
+// upload is an upload as returned by the Uploads API.
+type upload struct {
+	Complete bool    `json:"complete"`
+	Error    *string `json:"error"`
+	Id       string  `json:"id"`
+	Name     string  `json:"name"`
+	Progress float64 `json:"progress"`
+	Tileset  string  `json:"tileset"`
+}
+
+func (r *UploadResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_upload"
+}
+
+func (r *UploadResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a file such as MBTiles or a GeoTIFF to a tileset with the Uploads API. The file is staged in the S3 bucket of the API with temporary credentials and uploaded again when its content changes, waiting for the tileset to be processed. Destroying the resource removes the upload from the upload listing, the tileset is kept.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of the account that owns the tileset.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tileset_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the tileset in the form `USERNAME.TILESET-NAME`. The tileset is created by the upload, or replaced when it exists.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the tileset, the API derives one from the file when not set. Changing it uploads the file again.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of the file to upload.",
+				Required:            true,
+			},
+			"file_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of the uploaded file. Unset while the upload is processed or when it failed, so the file is uploaded again.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the last upload.",
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *UploadResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *UploadResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data UploadResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Username.IsUnknown() || data.TilesetId.IsUnknown() {
+		return
+	}
+
+	if !strings.HasPrefix(data.TilesetId.ValueString(), data.Username.ValueString()+".") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tileset_id"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("tileset_id must be in the form %s.TILESET-NAME, got: %q", data.Username.ValueString(), data.TilesetId.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan hashes the file during plan, so a changed file shows up as a new upload.
+func (r *UploadResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data, state UploadResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Path.IsUnknown() {
+		return
+	}
+
+	hash, err := hashFile(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to Read File", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_hash"), hash)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	id := state.Id
+	if hash != state.FileHash.ValueString() || !data.Name.Equal(state.Name) {
+		id = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (r *UploadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UploadResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Error", "Provider client is not configured")
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 60*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// The upload saves itself to state once it exists, so failed uploads are replaced.
+	resp.Diagnostics.Append(r.upload(ctx, &data, &resp.State)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created an upload", map[string]any{"id": data.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UploadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UploadResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := getUpload(r.client, data.Username.ValueString(), data.Id.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "upload not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read upload, got error: %s", err))
+		return
+	}
+
+	data.TilesetId = types.StringValue(current.Tileset)
+	data.Name = types.StringValue(current.Name)
+
+	// A failed upload has no file hash, so the next plan uploads the file again.
+	if current.Error != nil {
+		tflog.Warn(ctx, "upload failed, uploading the file again", map[string]any{"id": data.Id.ValueString(), "error": *current.Error})
+		data.FileHash = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UploadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state UploadResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Id.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 60*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.upload(ctx, &data, &resp.State)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UploadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UploadResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.Delete(uploadEndpoint(data.Username.ValueString(), data.Id.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete upload, got error: %s", err))
+		return
+	}
+
+	_ = httpResp.Body.Close()
+}
+
+func (r *UploadResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("unexpected format of ID (%q), expected USERNAME/UPLOAD-ID", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// upload stages the file in S3, creates the upload and waits for it to be processed. The
+// upload is saved to state without a file hash as soon as it exists, so an upload that fails
+// or times out is uploaded again by the next apply.
+func (r *UploadResource) upload(ctx context.Context, data *UploadResourceModel, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	username := data.Username.ValueString()
+
+	file, err := os.Open(data.Path.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("path"), "Unable to Read File", err.Error())
+		return diags
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		diags.AddAttributeError(path.Root("path"), "Unable to Read File", err.Error())
+		return diags
+	}
+
+	credentialsResp, err := r.client.Post(fmt.Sprintf("uploads/v1/%s/credentials", username), nil)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get upload credentials, got error: %s", err))
+		return diags
+	}
+
+	var credentials s3Credentials
+	if err := decodeJSON(credentialsResp, &credentials); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get upload credentials, got error: %s", err))
+		return diags
+	}
+
+	tflog.Info(ctx, "staging upload file", map[string]any{"path": data.Path.ValueString(), "size": info.Size(), "bucket": credentials.Bucket})
+
+	if err := r.client.PutS3Object(ctx, credentials, file, info.Size()); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to stage upload file, got error: %s", err))
+		return diags
+	}
+
+	body := map[string]any{"url": credentials.Url, "tileset": data.TilesetId.ValueString()}
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		body["name"] = data.Name.ValueString()
+	}
+
+	bytedata, err := json.Marshal(body)
+	if err != nil {
+		diags.AddError("Parsing Error", fmt.Sprintf("Unable to encode upload, got error: %s", err))
+		return diags
+	}
+
+	createResp, err := r.client.Post("uploads/v1/"+username, bytes.NewBuffer(bytedata))
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create upload, got error: %s", err))
+		return diags
+	}
+
+	var created upload
+	if err := decodeJSON(createResp, &created); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create upload, got error: %s", err))
+		return diags
+	}
+
+	data.Id = types.StringValue(created.Id)
+	data.Name = types.StringValue(created.Name)
+
+	pending := *data
+	pending.FileHash = types.StringNull()
+	diags.Append(state.Set(ctx, &pending)...)
+
+	finished, err := waitForUpload(ctx, r.client, username, created.Id)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to wait for upload %s, got error: %s", created.Id, err))
+		return diags
+	}
+
+	if finished.Error != nil {
+		diags.AddError("Upload Failed", fmt.Sprintf("Upload %s to tileset %s failed: %s", created.Id, finished.Tileset, *finished.Error))
+		return diags
+	}
+
+	data.Name = types.StringValue(finished.Name)
+
+	return diags
+}
+
+// waitForUpload polls an upload until it is complete or failed, logging its progress.
+func waitForUpload(ctx context.Context, client *Client, username, id string) (upload, error) {
+	progress := -1.0
+
+	for {
+		current, err := getUpload(client, username, id)
+		if err != nil {
+			return current, err
+		}
+
+		if current.Complete || current.Error != nil {
+			return current, nil
+		}
+
+		if current.Progress != progress {
+			progress = current.Progress
+			tflog.Info(ctx, "upload in progress", map[string]any{"id": id, "tileset": current.Tileset, "progress": fmt.Sprintf("%.0f%%", 100*progress)})
+		}
+
+		select {
+		case <-ctx.Done():
+			return current, fmt.Errorf("upload still at %.0f%%: %w", 100*current.Progress, ctx.Err())
+		case <-time.After(uploadPollInterval):
+		}
+	}
+}
+
+func getUpload(client *Client, username, id string) (upload, error) {
+	var current upload
+
+	resp, err := client.Get(uploadEndpoint(username, id))
+	if err != nil {
+		return current, err
+	}
+
+	err = decodeJSON(resp, &current)
+	return current, err
+}
+
+func uploadEndpoint(username, id string) string {
+	return fmt.Sprintf("uploads/v1/%s/%s", username, id)
+}
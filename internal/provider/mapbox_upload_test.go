@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccUploadResource_basic(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("uploads are only tested against mocks and a local S3 stand-in")
+	}
+
+	resourceName := "mapbox_upload.test"
+	username := "test"
+	tilesetId := username + ".tf-acc-test-upload"
+	s3Endpoint := "http://localhost:4566"
+
+	filePath := filepath.Join(t.TempDir(), "elevation.mbtiles")
+	writeFile := func(content string) {
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("not really mbtiles")
+
+	defer func(interval time.Duration) { uploadPollInterval = interval }(uploadPollInterval)
+	uploadPollInterval = time.Millisecond
+
+	var uploads, polls int
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Post(fmt.Sprintf("uploads/v1/%s/credentials", username)).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]any{
+			"accessKeyId":     "ASIATEST",
+			"bucket":          "tilestream-tilesets-production",
+			"key":             "_pending/test/staged",
+			"secretAccessKey": "secret",
+			"sessionToken":    "session-token",
+			"url":             "https://tilestream-tilesets-production.s3.amazonaws.com/_pending/test/staged",
+		})
+
+	gock.New(s3Endpoint).
+		Put("/tilestream-tilesets-production/_pending/test/staged").
+		MatchHeader("Authorization", "^AWS4-HMAC-SHA256 Credential=ASIATEST/").
+		MatchHeader("X-Amz-Security-Token", "session-token").
+		Persist().
+		Reply(http.StatusOK)
+
+	// The upload mocks go after the credentials mock, they would match its path too.
+	gock.New("https://api.mapbox.com").
+		Post("uploads/v1/"+username).
+		MatchParam("access_token", "test-token").
+		BodyString(`"url":"https://tilestream-tilesets-production.s3.amazonaws.com/_pending/test/staged"`).
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			uploads++
+			polls = 0
+			current := fmt.Sprintf(`{"id": "upload-%d", "complete": false, "progress": 0, "tileset": %q, "name": "Elevation"}`, uploads, tilesetId)
+			return mockBody(&current)(res)
+		})
+
+	gock.New("https://api.mapbox.com").
+		Get(fmt.Sprintf("uploads/v1/%s/upload-", username)).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			polls++
+			current := fmt.Sprintf(`{"id": "upload-%d", "complete": %t, "progress": %.1f, "error": null, "tileset": %q, "name": "Elevation"}`, uploads, polls > 2, min(float64(polls)/3, 1), tilesetId)
+			return mockBody(&current)(res)
+		})
+
+	gock.New("https://api.mapbox.com").
+		Delete(fmt.Sprintf("uploads/v1/%s/upload-2", username)).
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusNoContent)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccUploadResourceConfig(s3Endpoint, username, tilesetId, filePath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "upload-1"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Elevation"),
+					resource.TestCheckResourceAttrSet(resourceName, "file_hash"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           username + "/upload-1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"path", "file_hash", "timeouts"},
+			},
+			// Changed files are uploaded again
+			{
+				PreConfig: func() { writeFile("still not really mbtiles") },
+				Config:    testAccUploadResourceConfig(s3Endpoint, username, tilesetId, filePath),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("id")),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "id", "upload-2"),
+			},
+			// Unchanged files are not uploaded again
+			{
+				Config: testAccUploadResourceConfig(s3Endpoint, username, tilesetId, filePath),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("id"), knownvalue.StringExact("upload-2")),
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccUploadResource_failure(t *testing.T) {
+	if os.Getenv("MOCK") == "" {
+		t.Skip("failed uploads can only be tested against mocks")
+	}
+
+	resourceName := "mapbox_upload.test"
+	username := "test"
+	tilesetId := username + ".tf-acc-test-upload-failure"
+	s3Endpoint := "http://localhost:4566"
+
+	filePath := filepath.Join(t.TempDir(), "elevation.mbtiles")
+	writeFile := func(content string) {
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("not really mbtiles")
+
+	defer func(interval time.Duration) { uploadPollInterval = interval }(uploadPollInterval)
+	uploadPollInterval = time.Millisecond
+
+	// The second upload fails, the others complete right away.
+	var uploads int
+	defer gock.OffAll()
+
+	gock.New("https://api.mapbox.com").
+		Post(fmt.Sprintf("uploads/v1/%s/credentials", username)).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]any{
+			"accessKeyId":     "ASIATEST",
+			"bucket":          "tilestream-tilesets-production",
+			"key":             "_pending/test/staged",
+			"secretAccessKey": "secret",
+			"sessionToken":    "session-token",
+			"url":             "https://tilestream-tilesets-production.s3.amazonaws.com/_pending/test/staged",
+		})
+
+	gock.New(s3Endpoint).
+		Put("/tilestream-tilesets-production/_pending/test/staged").
+		Persist().
+		Reply(http.StatusOK)
+
+	gock.New("https://api.mapbox.com").
+		Post("uploads/v1/"+username).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			uploads++
+			current := fmt.Sprintf(`{"id": "upload-%d", "complete": false, "progress": 0, "tileset": %q, "name": "Elevation"}`, uploads, tilesetId)
+			return mockBody(&current)(res)
+		})
+
+	// The failed upload goes first, the mock of the other uploads would match its path too.
+	gock.New("https://api.mapbox.com").
+		Get(fmt.Sprintf("uploads/v1/%s/upload-2", username)).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]any{"id": "upload-2", "complete": false, "progress": 0, "error": "the file is not a valid MBTiles file", "tileset": tilesetId, "name": "Elevation"})
+
+	gock.New("https://api.mapbox.com").
+		Get(fmt.Sprintf("uploads/v1/%s/upload-", username)).
+		MatchParam("access_token", "test-token").
+		Persist().
+		Reply(http.StatusOK).
+		Map(func(res *http.Response) *http.Response {
+			current := fmt.Sprintf(`{"id": "upload-%d", "complete": true, "progress": 1, "error": null, "tileset": %q, "name": "Elevation"}`, uploads, tilesetId)
+			return mockBody(&current)(res)
+		})
+
+	gock.New("https://api.mapbox.com").
+		Delete(fmt.Sprintf("uploads/v1/%s/upload-3", username)).
+		MatchParam("access_token", "test-token").
+		Reply(http.StatusNoContent)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUploadResourceConfig(s3Endpoint, username, tilesetId, filePath),
+				Check:  resource.TestCheckResourceAttr(resourceName, "id", "upload-1"),
+			},
+			// The failed upload is kept without a file hash
+			{
+				PreConfig:   func() { writeFile("broken mbtiles") },
+				Config:      testAccUploadResourceConfig(s3Endpoint, username, tilesetId, filePath),
+				ExpectError: regexp.MustCompile(`the file is not a valid MBTiles file`),
+			},
+			// Applying again uploads the unchanged file again
+			{
+				Config: testAccUploadResourceConfig(s3Endpoint, username, tilesetId, filePath),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("id")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "upload-3"),
+					resource.TestCheckResourceAttrSet(resourceName, "file_hash"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccUploadResource_tilesetOwner(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUploadResourceConfig("http://localhost:4566", "test", "someone-else.elevation", "elevation.mbtiles"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`tileset_id must be in the form test.TILESET-NAME`),
+			},
+		},
+	})
+}
+
+func testAccUploadResourceConfig(s3Endpoint, username, tilesetId, filePath string) string {
+	return fmt.Sprintf(`
+provider "mapbox" {
+  s3_endpoint = %[1]q
+}
+
+resource "mapbox_upload" "test" {
+  username   = %[2]q
+  tileset_id = %[3]q
+  path       = %[4]q
+}
+`, s3Endpoint, username, tilesetId, filePath)
+}
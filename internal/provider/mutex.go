@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "sync"
+
+// keyedMutex serializes read-modify-write API calls on the same object, e.g. several
+// resources patching their part of one style document in parallel.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (m *keyedMutex) Lock(key string) {
+	m.get(key).Lock()
+}
+
+func (m *keyedMutex) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *keyedMutex) get(key string) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.locks == nil {
+		m.locks = map[string]*sync.Mutex{}
+	}
+
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+
+	return lock
+}
+
+// styleLocks guards the documents of styles that are patched by more than one resource.
+var styleLocks keyedMutex
@@ -29,6 +29,7 @@ type MapBoxProvider struct {
 // MapBoxProviderModel describes the provider data model.
 type MapBoxProviderModel struct {
 	AccessToken types.String `tfsdk:"access_token"`
+	S3Endpoint  types.String `tfsdk:"s3_endpoint"`
 }
 
 func (p *MapBoxProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -44,6 +45,10 @@ func (p *MapBoxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"s3_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Endpoint of an S3 compatible server that replaces the Amazon S3 staging bucket of the Uploads API, e.g. a local stand-in for testing. Defaults to the `MAPBOX_S3_ENDPOINT` environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -82,6 +87,12 @@ func (p *MapBoxProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	client.AccessToken = &accessToken
+
+	client.S3Endpoint = os.Getenv("MAPBOX_S3_ENDPOINT")
+	if data.S3Endpoint.ValueString() != "" {
+		client.S3Endpoint = data.S3Endpoint.ValueString()
+	}
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
@@ -89,12 +100,29 @@ func (p *MapBoxProvider) Configure(ctx context.Context, req provider.ConfigureRe
 func (p *MapBoxProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewTokenResource,
+		NewStyleResource,
+		NewStyleLayerResource,
+		NewStyleIconResource,
+		NewStyleSpriteResource,
+		NewFontResource,
+		NewTilesetSourceResource,
+		NewTilesetResource,
+		NewUploadResource,
+		NewDatasetResource,
+		NewDatasetFeatureResource,
+		NewDatasetFeaturesResource,
 	}
 }
 
 func (p *MapBoxProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		// NewExampleDataSource,
+		NewFontsDataSource,
+		NewStylesDataSource,
+		NewStyleDocumentDataSource,
+		NewTilesetJobsDataSource,
+		NewTilesetJobDataSource,
+		NewTilesetMetadataDataSource,
+		NewTilesetsDataSource,
 	}
 }
 
@@ -5,11 +5,16 @@ package provider
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func init() {
@@ -37,3 +42,40 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// mockBody replaces the body of a mocked response with the current value of body, so a
+// persisted GET mock follows the changes made by create and update mocks.
+func mockBody(body *string) func(*http.Response) *http.Response {
+	return func(res *http.Response) *http.Response {
+		res.Body = io.NopCloser(strings.NewReader(*body))
+		res.ContentLength = int64(len(*body))
+		return res
+	}
+}
+
+// mockSaveBody stores the body of the mocked request in body and echoes it back, so later
+// responses built with mockBody return what was last saved.
+func mockSaveBody(body *string) func(*http.Response) *http.Response {
+	return func(res *http.Response) *http.Response {
+		if res.Request != nil && res.Request.Body != nil {
+			saved, err := io.ReadAll(res.Request.Body)
+			if err == nil {
+				*body = string(saved)
+			}
+		}
+
+		return mockBody(body)(res)
+	}
+}
+
+// testAccUsernameImportStateId returns the import ID of resources imported as USERNAME/ID.
+func testAccUsernameImportStateId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["username"], rs.Primary.ID), nil
+	}
+}
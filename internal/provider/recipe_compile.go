@@ -0,0 +1,338 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tilesetLayerModel describes a layer block of a tileset recipe.
+type tilesetLayerModel struct {
+	Features *tilesetLayerFeaturesModel `tfsdk:"features"`
+	Maxzoom  types.Int64                `tfsdk:"maxzoom"`
+	Minzoom  types.Int64                `tfsdk:"minzoom"`
+	Name     types.String               `tfsdk:"name"`
+	Source   types.String               `tfsdk:"source"`
+	Tiles    *tilesetLayerTilesModel    `tfsdk:"tiles"`
+}
+
+type tilesetLayerFeaturesModel struct {
+	Attributes     *tilesetLayerAttributesModel `tfsdk:"attributes"`
+	Filter         types.String                 `tfsdk:"filter"`
+	Simplification types.Float64                `tfsdk:"simplification"`
+}
+
+type tilesetLayerAttributesModel struct {
+	AllowedOutput []string          `tfsdk:"allowed_output"`
+	Set           map[string]string `tfsdk:"set"`
+	ZoomElement   []string          `tfsdk:"zoom_element"`
+}
+
+type tilesetLayerTilesModel struct {
+	BufferSize types.Int64              `tfsdk:"buffer_size"`
+	Extent     types.Int64              `tfsdk:"extent"`
+	Filter     types.String             `tfsdk:"filter"`
+	LayerSize  types.Int64              `tfsdk:"layer_size"`
+	Order      types.String             `tfsdk:"order"`
+	Union      []tilesetLayerUnionModel `tfsdk:"union"`
+}
+
+type tilesetLayerUnionModel struct {
+	Aggregate         map[string]string `tfsdk:"aggregate"`
+	GroupBy           []string          `tfsdk:"group_by"`
+	MaintainDirection types.Bool        `tfsdk:"maintain_direction"`
+	MaxMergedFeatures types.Int64       `tfsdk:"max_merged_features"`
+	Where             types.String      `tfsdk:"where"`
+}
+
+const expressionDescription = " Expressions are given as JSON, e.g. with `jsonencode`."
+
+// tilesetLayerBlock is the schema of the layer blocks that are compiled into a recipe.
+func tilesetLayerBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "A layer of the tileset, compiled into a recipe together with the other `layer` blocks. Conflicts with `recipe`.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "The name of the layer in the vector tiles.",
+					Required:            true,
+				},
+				"source": schema.StringAttribute{
+					MarkdownDescription: "The `mapbox://tileset-source/` URL of the tileset source of the layer.",
+					Required:            true,
+				},
+				"minzoom": schema.Int64Attribute{
+					MarkdownDescription: "The lowest zoom level tiles are generated for, between 0 and 16.",
+					Required:            true,
+				},
+				"maxzoom": schema.Int64Attribute{
+					MarkdownDescription: "The highest zoom level tiles are generated for, between 0 and 16.",
+					Required:            true,
+				},
+			},
+			Blocks: map[string]schema.Block{
+				"features": schema.SingleNestedBlock{
+					MarkdownDescription: "How the features of the source are processed before they are tiled.",
+					Attributes: map[string]schema.Attribute{
+						"filter": schema.StringAttribute{
+							MarkdownDescription: "An expression that keeps only the features it is true for." + expressionDescription,
+							Optional:            true,
+						},
+						"simplification": schema.Float64Attribute{
+							MarkdownDescription: "How much the geometries are simplified, the API defaults to 4.",
+							Optional:            true,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"attributes": schema.SingleNestedBlock{
+							MarkdownDescription: "Which feature properties end up in the tiles.",
+							Attributes: map[string]schema.Attribute{
+								"allowed_output": schema.ListAttribute{
+									MarkdownDescription: "The properties kept in the tiles, all properties are kept when not set.",
+									Optional:            true,
+									ElementType:         types.StringType,
+								},
+								"set": schema.MapAttribute{
+									MarkdownDescription: "Properties to add or replace, keyed by property name, with expressions as values." + expressionDescription,
+									Optional:            true,
+									ElementType:         types.StringType,
+								},
+								"zoom_element": schema.ListAttribute{
+									MarkdownDescription: "Array properties whose value is picked by zoom level.",
+									Optional:            true,
+									ElementType:         types.StringType,
+								},
+							},
+						},
+					},
+				},
+				"tiles": schema.SingleNestedBlock{
+					MarkdownDescription: "How the features of the layer are tiled.",
+					Attributes: map[string]schema.Attribute{
+						"buffer_size": schema.Int64Attribute{
+							MarkdownDescription: "The buffer around each tile, in tile units.",
+							Optional:            true,
+						},
+						"extent": schema.Int64Attribute{
+							MarkdownDescription: "The resolution of the tiles, the API defaults to 4096.",
+							Optional:            true,
+						},
+						"filter": schema.StringAttribute{
+							MarkdownDescription: "An expression that keeps only the features it is true for, evaluated per zoom level." + expressionDescription,
+							Optional:            true,
+						},
+						"layer_size": schema.Int64Attribute{
+							MarkdownDescription: "The maximum size of the layer in each tile, in KiB. Features are dropped from tiles that exceed it.",
+							Optional:            true,
+						},
+						"order": schema.StringAttribute{
+							MarkdownDescription: "The property features are sorted by within a tile, prefix with `-` for a descending order.",
+							Optional:            true,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"union": schema.ListNestedBlock{
+							MarkdownDescription: "Merges features that share the same `group_by` properties.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"where": schema.StringAttribute{
+										MarkdownDescription: "An expression that selects the features that are merged." + expressionDescription,
+										Optional:            true,
+									},
+									"group_by": schema.ListAttribute{
+										MarkdownDescription: "The properties whose values must be equal for features to be merged.",
+										Optional:            true,
+										ElementType:         types.StringType,
+									},
+									"aggregate": schema.MapAttribute{
+										MarkdownDescription: "How the other properties are combined, keyed by property name, e.g. `sum` or `max`.",
+										Optional:            true,
+										ElementType:         types.StringType,
+									},
+									"maintain_direction": schema.BoolAttribute{
+										MarkdownDescription: "Whether lines are only merged when their directions match.",
+										Optional:            true,
+									},
+									"max_merged_features": schema.Int64Attribute{
+										MarkdownDescription: "The maximum number of features merged into one.",
+										Optional:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// compileRecipe turns the layer blocks into a recipe. The recipe is unknown until all
+// values of the blocks are known.
+func compileRecipe(ctx context.Context, layers types.List) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !isFullyKnown(ctx, layers) {
+		return types.StringUnknown(), diags
+	}
+
+	var models []tilesetLayerModel
+	diags.Append(layers.ElementsAs(ctx, &models, false)...)
+
+	if diags.HasError() {
+		return types.StringNull(), diags
+	}
+
+	compiled := map[string]any{}
+
+	for i, model := range models {
+		layerPath := path.Root("layer").AtListIndex(i)
+		name := model.Name.ValueString()
+
+		if _, ok := compiled[name]; ok {
+			diags.AddAttributeError(layerPath.AtName("name"), "Duplicate Layer Name", fmt.Sprintf("The layer name %q is used more than once.", name))
+			continue
+		}
+
+		layer := map[string]any{
+			"source":  model.Source.ValueString(),
+			"minzoom": model.Minzoom.ValueInt64(),
+			"maxzoom": model.Maxzoom.ValueInt64(),
+		}
+
+		if model.Features != nil {
+			layer["features"] = model.Features.compile(layerPath.AtName("features"), &diags)
+		}
+
+		if model.Tiles != nil {
+			layer["tiles"] = model.Tiles.compile(layerPath.AtName("tiles"), &diags)
+		}
+
+		compiled[name] = layer
+	}
+
+	if diags.HasError() {
+		return types.StringNull(), diags
+	}
+
+	recipe, err := json.Marshal(map[string]any{"version": 1, "layers": compiled})
+	if err != nil {
+		diags.AddAttributeError(path.Root("layer"), "Parsing Error", fmt.Sprintf("Unable to encode recipe, got error: %s", err))
+		return types.StringNull(), diags
+	}
+
+	return types.StringValue(string(recipe)), diags
+}
+
+func (m tilesetLayerFeaturesModel) compile(p path.Path, diags *diag.Diagnostics) map[string]any {
+	features := map[string]any{}
+
+	setExpression(features, "filter", m.Filter, p.AtName("filter"), diags)
+
+	if !m.Simplification.IsNull() {
+		features["simplification"] = m.Simplification.ValueFloat64()
+	}
+
+	if m.Attributes != nil {
+		attributes := map[string]any{}
+
+		if m.Attributes.AllowedOutput != nil {
+			attributes["allowed_output"] = m.Attributes.AllowedOutput
+		}
+
+		if m.Attributes.ZoomElement != nil {
+			attributes["zoom_element"] = m.Attributes.ZoomElement
+		}
+
+		if m.Attributes.Set != nil {
+			set := map[string]any{}
+			for name, value := range m.Attributes.Set {
+				setExpression(set, name, types.StringValue(value), p.AtName("attributes").AtName("set").AtMapKey(name), diags)
+			}
+
+			attributes["set"] = set
+		}
+
+		features["attributes"] = attributes
+	}
+
+	return features
+}
+
+func (m tilesetLayerTilesModel) compile(p path.Path, diags *diag.Diagnostics) map[string]any {
+	tiles := map[string]any{}
+
+	for name, value := range map[string]types.Int64{"buffer_size": m.BufferSize, "extent": m.Extent, "layer_size": m.LayerSize} {
+		if !value.IsNull() {
+			tiles[name] = value.ValueInt64()
+		}
+	}
+
+	if !m.Order.IsNull() {
+		tiles["order"] = m.Order.ValueString()
+	}
+
+	setExpression(tiles, "filter", m.Filter, p.AtName("filter"), diags)
+
+	if len(m.Union) > 0 {
+		unions := make([]map[string]any, 0, len(m.Union))
+
+		for i, model := range m.Union {
+			union := map[string]any{}
+
+			setExpression(union, "where", model.Where, p.AtName("union").AtListIndex(i).AtName("where"), diags)
+
+			if model.GroupBy != nil {
+				union["group_by"] = model.GroupBy
+			}
+
+			if model.Aggregate != nil {
+				union["aggregate"] = model.Aggregate
+			}
+
+			if !model.MaintainDirection.IsNull() {
+				union["maintain_direction"] = model.MaintainDirection.ValueBool()
+			}
+
+			if !model.MaxMergedFeatures.IsNull() {
+				union["max_merged_features"] = model.MaxMergedFeatures.ValueInt64()
+			}
+
+			unions = append(unions, union)
+		}
+
+		tiles["union"] = unions
+	}
+
+	return tiles
+}
+
+// setExpression decodes a JSON expression into target, reporting invalid JSON at p.
+func setExpression(target map[string]any, key string, value types.String, p path.Path, diags *diag.Diagnostics) {
+	if value.IsNull() {
+		return
+	}
+
+	var expression any
+	if err := json.Unmarshal([]byte(value.ValueString()), &expression); err != nil {
+		diags.AddAttributeError(p, "Invalid Expression", fmt.Sprintf("The expression is not valid JSON: %s", err))
+		return
+	}
+
+	target[key] = expression
+}
+
+// isFullyKnown reports whether a value and everything nested in it is known.
+func isFullyKnown(ctx context.Context, value attr.Value) bool {
+	tfValue, err := value.ToTerraformValue(ctx)
+	return err == nil && tfValue.IsFullyKnown()
+}
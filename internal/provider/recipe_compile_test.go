@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCompileRecipe(t *testing.T) {
+	ctx := context.Background()
+	layerType := tilesetLayerBlock().NestedObject.Type()
+
+	layers, diags := types.ListValueFrom(ctx, layerType, []tilesetLayerModel{
+		{
+			Name:    types.StringValue("roads"),
+			Source:  types.StringValue("mapbox://tileset-source/example/roads"),
+			Minzoom: types.Int64Value(4),
+			Maxzoom: types.Int64Value(14),
+			Features: &tilesetLayerFeaturesModel{
+				Filter:         types.StringValue(`["!=", ["get", "class"], "path"]`),
+				Simplification: types.Float64Null(),
+				Attributes: &tilesetLayerAttributesModel{
+					AllowedOutput: []string{"class", "name"},
+					Set:           map[string]string{"label": `["upcase", ["get", "name"]]`},
+				},
+			},
+			Tiles: &tilesetLayerTilesModel{
+				BufferSize: types.Int64Null(),
+				Extent:     types.Int64Null(),
+				Filter:     types.StringNull(),
+				LayerSize:  types.Int64Value(2500),
+				Order:      types.StringValue("-rank"),
+				Union: []tilesetLayerUnionModel{
+					{
+						GroupBy:           []string{"class"},
+						MaintainDirection: types.BoolValue(true),
+						MaxMergedFeatures: types.Int64Null(),
+						Where:             types.StringNull(),
+					},
+				},
+			},
+		},
+		{
+			Name:    types.StringValue("places"),
+			Source:  types.StringValue("mapbox://tileset-source/example/places"),
+			Minzoom: types.Int64Value(0),
+			Maxzoom: types.Int64Value(10),
+		},
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	recipe, diags := compileRecipe(ctx, layers)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	expected := `{"layers":{"places":{"maxzoom":10,"minzoom":0,"source":"mapbox://tileset-source/example/places"},` +
+		`"roads":{"features":{"attributes":{"allowed_output":["class","name"],"set":{"label":["upcase",["get","name"]]}},"filter":["!=",["get","class"],"path"]},` +
+		`"maxzoom":14,"minzoom":4,"source":"mapbox://tileset-source/example/roads",` +
+		`"tiles":{"layer_size":2500,"order":"-rank","union":[{"group_by":["class"],"maintain_direction":true}]}}},"version":1}`
+
+	if recipe.ValueString() != expected {
+		t.Errorf("unexpected recipe:\n%s", recipe.ValueString())
+	}
+
+	if recipe, _ := compileRecipe(ctx, types.ListUnknown(layerType)); !recipe.IsUnknown() {
+		t.Errorf("expected an unknown recipe for unknown layers, got %s", recipe)
+	}
+}
+
+func TestCompileRecipe_invalid(t *testing.T) {
+	ctx := context.Background()
+
+	layer := tilesetLayerModel{
+		Name:    types.StringValue("roads"),
+		Source:  types.StringValue("mapbox://tileset-source/example/roads"),
+		Minzoom: types.Int64Value(0),
+		Maxzoom: types.Int64Value(10),
+		Features: &tilesetLayerFeaturesModel{
+			Filter:         types.StringValue(`["==", "class"`),
+			Simplification: types.Float64Null(),
+		},
+	}
+
+	layers, diags := types.ListValueFrom(ctx, tilesetLayerBlock().NestedObject.Type(), []tilesetLayerModel{layer, layer})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	_, diags = compileRecipe(ctx, layers)
+
+	var summaries []string
+	for _, d := range diags.Errors() {
+		summaries = append(summaries, d.Summary())
+	}
+
+	if got := strings.Join(summaries, ", "); got != "Invalid Expression, Duplicate Layer Name" {
+		t.Errorf("unexpected errors: %s", got)
+	}
+}
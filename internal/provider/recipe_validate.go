@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+)
+
+// recipeMaxZoom is the highest zoom level the Mapbox Tiling Service generates vector tiles for.
+const recipeMaxZoom = 16
+
+var tilesetSourceRe = regexp.MustCompile(`^mapbox://tileset-source/[^/]+/[a-zA-Z0-9_-]{1,32}$`)
+
+// recipeError is a problem found in a tileset recipe, tied to the layer it was found in.
+type recipeError struct {
+	Layer   string
+	Message string
+}
+
+func (e recipeError) Error() string {
+	if e.Layer != "" {
+		return fmt.Sprintf("layer %q: %s", e.Layer, e.Message)
+	}
+
+	return e.Message
+}
+
+// validateRecipeDocument checks the structure of a vector tileset recipe without calling
+// the API. The validateRecipe endpoint checks everything else.
+func validateRecipeDocument(doc map[string]any) []recipeError {
+	var errs []recipeError
+
+	if version, ok := doc["version"].(float64); !ok || version != 1 {
+		errs = append(errs, recipeError{Message: "version must be 1"})
+	}
+
+	// Raster recipes have a different structure, which is left to the API.
+	if recipeType, ok := doc["type"].(string); ok && recipeType != "vector" {
+		return errs
+	}
+
+	layers, ok := doc["layers"].(map[string]any)
+	if !ok || len(layers) == 0 {
+		return append(errs, recipeError{Message: "layers must be an object with at least one layer"})
+	}
+
+	for _, name := range sortedKeys(layers) {
+		layer, ok := layers[name].(map[string]any)
+		if !ok {
+			errs = append(errs, recipeError{Layer: name, Message: "layer must be an object"})
+			continue
+		}
+
+		for _, message := range validateRecipeLayer(layer) {
+			errs = append(errs, recipeError{Layer: name, Message: message})
+		}
+	}
+
+	return errs
+}
+
+func validateRecipeLayer(layer map[string]any) []string {
+	var messages []string
+
+	switch source := layer["source"].(type) {
+	case string:
+		if !tilesetSourceRe.MatchString(source) {
+			messages = append(messages, fmt.Sprintf("source %q must be a mapbox://tileset-source/USERNAME/SOURCE-ID URL", source))
+		}
+	case nil:
+		messages = append(messages, "source is required")
+	default:
+		messages = append(messages, "source must be a string")
+	}
+
+	zooms := map[string]float64{}
+	for _, property := range []string{"minzoom", "maxzoom"} {
+		value, ok := layer[property]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("%s is required", property))
+			continue
+		}
+
+		zoom, ok := value.(float64)
+		if !ok || zoom < 0 || zoom > recipeMaxZoom || zoom != math.Trunc(zoom) {
+			messages = append(messages, fmt.Sprintf("%s must be a whole number between 0 and %d", property, recipeMaxZoom))
+			continue
+		}
+
+		zooms[property] = zoom
+	}
+
+	if minzoom, ok := zooms["minzoom"]; ok {
+		if maxzoom, ok := zooms["maxzoom"]; ok && minzoom > maxzoom {
+			messages = append(messages, "minzoom must not be greater than maxzoom")
+		}
+	}
+
+	sort.Strings(messages)
+	return messages
+}
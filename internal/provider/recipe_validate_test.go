@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateRecipeDocument(t *testing.T) {
+	cases := map[string]struct {
+		recipe string
+		errors []string
+	}{
+		"valid": {
+			recipe: `{
+  "version": 1,
+  "layers": {
+    "roads": {"source": "mapbox://tileset-source/example/roads", "minzoom": 4, "maxzoom": 14},
+    "places": {"source": "mapbox://tileset-source/example/places", "minzoom": 0, "maxzoom": 16, "features": {"attributes": {"allowed_output": ["name"]}}}
+  }
+}`,
+		},
+		"raster": {
+			recipe: `{"version": 1, "type": "rasterarray", "sources": [{"uri": "mapbox://tileset-source/example/dem"}], "minzoom": 0, "maxzoom": 6}`,
+		},
+		"no layers": {
+			recipe: `{"version": 2, "layers": {}}`,
+			errors: []string{
+				`version must be 1`,
+				`layers must be an object with at least one layer`,
+			},
+		},
+		"invalid layers": {
+			recipe: `{
+  "version": 1,
+  "layers": {
+    "roads": {"source": "mapbox://tileset-source/example/roads", "minzoom": 14, "maxzoom": 4},
+    "places": {"source": "mapbox://tilesets/example.places", "minzoom": 0.5, "maxzoom": 18},
+    "water": {"maxzoom": 10},
+    "buildings": []
+  }
+}`,
+			errors: []string{
+				`layer "buildings": layer must be an object`,
+				`layer "places": maxzoom must be a whole number between 0 and 16`,
+				`layer "places": minzoom must be a whole number between 0 and 16`,
+				`layer "places": source "mapbox://tilesets/example.places" must be a mapbox://tileset-source/USERNAME/SOURCE-ID URL`,
+				`layer "roads": minzoom must not be greater than maxzoom`,
+				`layer "water": minzoom is required`,
+				`layer "water": source is required`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var doc map[string]any
+			if err := json.Unmarshal([]byte(tc.recipe), &doc); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, err := range validateRecipeDocument(doc) {
+				got = append(got, err.Error())
+			}
+
+			if !reflect.DeepEqual(got, tc.errors) {
+				t.Errorf("unexpected errors:\n%#v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// sourceFormat is the format of a file uploaded to a tileset source, detected from its extension.
+type sourceFormat string
+
+const (
+	sourceFormatLineDelimited     sourceFormat = "line-delimited GeoJSON"
+	sourceFormatFeatureCollection sourceFormat = "GeoJSON"
+	sourceFormatCSV               sourceFormat = "CSV"
+	sourceFormatShapefile         sourceFormat = "Shapefile"
+)
+
+var (
+	csvLatitudeColumns  = []string{"lat", "latitude", "y"}
+	csvLongitudeColumns = []string{"lon", "lng", "long", "longitude", "x"}
+)
+
+// featureError is an invalid feature of a source file, located by line, feature or record
+// depending on the format.
+type featureError struct {
+	Location string
+	Message  string
+}
+
+func (e featureError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Location, e.Message)
+}
+
+func detectSourceFormat(p string) sourceFormat {
+	switch strings.ToLower(filepath.Ext(p)) {
+	case ".geojson", ".json":
+		return sourceFormatFeatureCollection
+	case ".csv":
+		return sourceFormatCSV
+	case ".zip":
+		return sourceFormatShapefile
+	}
+
+	return sourceFormatLineDelimited
+}
+
+// lineDelimitedName is the name a file is uploaded with after its conversion.
+func lineDelimitedName(p string) string {
+	name := filepath.Base(p)
+	if detectSourceFormat(p) == sourceFormatLineDelimited {
+		return name
+	}
+
+	return strings.TrimSuffix(name, filepath.Ext(name)) + ".geojsonld"
+}
+
+// convertSourceFile writes the features of the file at p to w as line-delimited GeoJSON.
+// Features with invalid geometries are skipped and returned, the error is only set when the
+// file as a whole cannot be read.
+func convertSourceFile(p string, w io.Writer) ([]featureError, error) {
+	if detectSourceFormat(p) == sourceFormatShapefile {
+		return convertShapefile(p, w)
+	}
+
+	file, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	out := bufio.NewWriter(w)
+
+	var invalid []featureError
+
+	switch detectSourceFormat(p) {
+	case sourceFormatFeatureCollection:
+		invalid, err = convertFeatureCollection(file, out)
+	case sourceFormatCSV:
+		invalid, err = convertCSV(file, out)
+	default:
+		invalid, err = convertLineDelimited(file, out)
+	}
+
+	if err == nil {
+		err = out.Flush()
+	}
+
+	return invalid, err
+}
+
+// convertLineDelimited validates line-delimited GeoJSON, copying the valid lines as they are.
+func convertLineDelimited(r io.Reader, w *bufio.Writer) ([]featureError, error) {
+	var invalid []featureError
+
+	reader := bufio.NewReader(r)
+
+	for line := 1; ; line++ {
+		content, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return invalid, err
+		}
+
+		if content = bytes.TrimSpace(content); len(content) > 0 {
+			if featureErr := validateFeature(content); featureErr != nil {
+				invalid = append(invalid, featureError{fmt.Sprintf("line %d", line), featureErr.Error()})
+			} else if err := writeLine(w, content); err != nil {
+				return invalid, err
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return invalid, nil
+		}
+	}
+}
+
+// convertFeatureCollection streams the features of a FeatureCollection one by one, without
+// holding the whole collection in memory.
+func convertFeatureCollection(r io.Reader, w *bufio.Writer) ([]featureError, error) {
+	var invalid []featureError
+
+	decoder := json.NewDecoder(r)
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("not a GeoJSON FeatureCollection")
+	}
+
+	found := false
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return invalid, err
+		}
+
+		if token != "features" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return invalid, err
+			}
+			continue
+		}
+
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return invalid, fmt.Errorf("the features of the FeatureCollection are not an array")
+		}
+
+		found = true
+
+		for i := 1; decoder.More(); i++ {
+			var feature json.RawMessage
+			if err := decoder.Decode(&feature); err != nil {
+				return invalid, fmt.Errorf("feature %d: %w", i, err)
+			}
+
+			if featureErr := validateFeature(feature); featureErr != nil {
+				invalid = append(invalid, featureError{fmt.Sprintf("feature %d", i), featureErr.Error()})
+				continue
+			}
+
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, feature); err != nil {
+				return invalid, err
+			}
+
+			if err := writeLine(w, compact.Bytes()); err != nil {
+				return invalid, err
+			}
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return invalid, err
+		}
+	}
+
+	if !found {
+		return invalid, fmt.Errorf("not a GeoJSON FeatureCollection, it has no features")
+	}
+
+	return invalid, nil
+}
+
+// convertCSV turns the rows of a CSV file into point features, with the latitude and
+// longitude columns as coordinates and the other columns as string properties.
+func convertCSV(r io.Reader, w *bufio.Writer) ([]featureError, error) {
+	var invalid []featureError
+
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	lat, lon := -1, -1
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+
+		switch {
+		case lat < 0 && slices.Contains(csvLatitudeColumns, column):
+			lat = i
+		case lon < 0 && slices.Contains(csvLongitudeColumns, column):
+			lon = i
+		}
+	}
+
+	if lat < 0 || lon < 0 {
+		return nil, fmt.Errorf("the CSV header needs a latitude column (%s) and a longitude column (%s)", strings.Join(csvLatitudeColumns, ", "), strings.Join(csvLongitudeColumns, ", "))
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return invalid, nil
+		}
+
+		line, _ := reader.FieldPos(0)
+		location := fmt.Sprintf("line %d", line)
+
+		if errors.Is(err, csv.ErrFieldCount) {
+			invalid = append(invalid, featureError{location, fmt.Sprintf("expected %d columns, got %d", len(header), len(record))})
+			continue
+		}
+		if err != nil {
+			return invalid, err
+		}
+
+		x, lonErr := strconv.ParseFloat(strings.TrimSpace(record[lon]), 64)
+		y, latErr := strconv.ParseFloat(strings.TrimSpace(record[lat]), 64)
+
+		if lonErr != nil || latErr != nil {
+			invalid = append(invalid, featureError{location, fmt.Sprintf("the coordinates %q, %q are not numbers", record[lon], record[lat])})
+			continue
+		}
+
+		coordinates := []float64{x, y}
+		if err := validatePosition(coordinates); err != nil {
+			invalid = append(invalid, featureError{location, err.Error()})
+			continue
+		}
+
+		properties := map[string]any{}
+		for i, value := range record {
+			if i != lat && i != lon {
+				properties[header[i]] = value
+			}
+		}
+
+		if err := writeFeature(w, map[string]any{"type": "Point", "coordinates": coordinates}, properties); err != nil {
+			return invalid, err
+		}
+	}
+}
+
+func writeFeature(w *bufio.Writer, geometry, properties map[string]any) error {
+	line, err := json.Marshal(map[string]any{"type": "Feature", "geometry": geometry, "properties": properties})
+	if err != nil {
+		return err
+	}
+
+	return writeLine(w, line)
+}
+
+func writeLine(w *bufio.Writer, line []byte) error {
+	if _, err := w.Write(line); err != nil {
+		return err
+	}
+
+	return w.WriteByte('\n')
+}
+
+// validateFeature checks that content is a GeoJSON feature with a valid geometry.
+func validateFeature(content []byte) error {
+	var feature struct {
+		Type     string          `json:"type"`
+		Geometry json.RawMessage `json:"geometry"`
+	}
+
+	if err := json.Unmarshal(content, &feature); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if feature.Type != "Feature" {
+		return fmt.Errorf("expected a Feature, got type %q", feature.Type)
+	}
+
+	if len(feature.Geometry) == 0 || string(feature.Geometry) == "null" {
+		return fmt.Errorf("the feature has no geometry")
+	}
+
+	return validateGeometry(feature.Geometry)
+}
+
+// validateGeometry checks the structure and coordinates of a GeoJSON geometry: positions are
+// within the WGS 84 range, lines have at least two positions and polygon rings are closed.
+func validateGeometry(content json.RawMessage) error {
+	var geometry struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometries  []json.RawMessage `json:"geometries"`
+	}
+
+	if err := json.Unmarshal(content, &geometry); err != nil {
+		return fmt.Errorf("invalid geometry: %w", err)
+	}
+
+	if geometry.Type == "GeometryCollection" {
+		for i, member := range geometry.Geometries {
+			if err := validateGeometry(member); err != nil {
+				return fmt.Errorf("geometry %d: %w", i+1, err)
+			}
+		}
+
+		return nil
+	}
+
+	var err error
+
+	switch geometry.Type {
+	case "Point":
+		var position []float64
+		if err = json.Unmarshal(geometry.Coordinates, &position); err == nil {
+			err = validatePosition(position)
+		}
+	case "MultiPoint":
+		var positions [][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &positions); err == nil {
+			err = validatePositions(positions, 1)
+		}
+	case "LineString":
+		var line [][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &line); err == nil {
+			err = validatePositions(line, 2)
+		}
+	case "MultiLineString":
+		var lines [][][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &lines); err == nil {
+			for _, line := range lines {
+				if err = validatePositions(line, 2); err != nil {
+					break
+				}
+			}
+		}
+	case "Polygon":
+		var rings [][][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &rings); err == nil {
+			err = validatePolygon(rings)
+		}
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &polygons); err == nil {
+			for _, rings := range polygons {
+				if err = validatePolygon(rings); err != nil {
+					break
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("unknown geometry type %q", geometry.Type)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", geometry.Type, err)
+	}
+
+	return nil
+}
+
+func validatePolygon(rings [][][]float64) error {
+	if len(rings) == 0 {
+		return fmt.Errorf("a polygon needs at least one ring")
+	}
+
+	for _, ring := range rings {
+		if err := validatePositions(ring, 4); err != nil {
+			return err
+		}
+
+		if !slices.Equal(ring[0], ring[len(ring)-1]) {
+			return fmt.Errorf("the ring is not closed, its first and last position differ")
+		}
+	}
+
+	return nil
+}
+
+func validatePositions(positions [][]float64, minimum int) error {
+	if len(positions) < minimum {
+		return fmt.Errorf("expected at least %d positions, got %d", minimum, len(positions))
+	}
+
+	for _, position := range positions {
+		if err := validatePosition(position); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validatePosition(position []float64) error {
+	if len(position) < 2 {
+		return fmt.Errorf("a position needs a longitude and latitude, got %v", position)
+	}
+
+	lon, lat := position[0], position[1]
+
+	if math.IsNaN(lon) || math.IsNaN(lat) || lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+		return fmt.Errorf("the position %v is outside of the longitude and latitude range", position)
+	}
+
+	return nil
+}
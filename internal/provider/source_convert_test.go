@@ -0,0 +1,240 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertSourceFile(t *testing.T) {
+	cases := map[string]struct {
+		content  string
+		expected string
+		invalid  []string
+	}{
+		"points.geojsonld": {
+			content: `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [13.4, 52.5]}, "properties": {}}
+
+{"type": "Feature", "geometry": {"type": "Point", "coordinates": [200, 52.5]}, "properties": {}}
+{"type": "Feature", "geometry": null, "properties": {}}
+`,
+			expected: `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [13.4, 52.5]}, "properties": {}}` + "\n",
+			invalid: []string{
+				"line 3: invalid Point: the position [200 52.5] is outside of the longitude and latitude range",
+				"line 4: the feature has no geometry",
+			},
+		},
+		"areas.geojson": {
+			content: `{
+  "type": "FeatureCollection",
+  "name": "areas",
+  "features": [
+    {"type": "Feature", "id": 1, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}, "properties": {"name": "closed"}},
+    {"type": "Feature", "id": 2, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]}, "properties": {"name": "open"}},
+    {"type": "Feature", "id": 3, "geometry": {"type": "LineString", "coordinates": [[0, 0]]}, "properties": {}}
+  ]
+}`,
+			expected: `{"type":"Feature","id":1,"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]},"properties":{"name":"closed"}}` + "\n",
+			invalid: []string{
+				"feature 2: invalid Polygon: the ring is not closed, its first and last position differ",
+				"feature 3: invalid LineString: expected at least 2 positions, got 1",
+			},
+		},
+		"stores.csv": {
+			content:  "\ufeffname,Latitude,Longitude\nBerlin,52.5,13.4\nNowhere,,\nShort,1\n",
+			expected: `{"geometry":{"coordinates":[13.4,52.5],"type":"Point"},"properties":{"name":"Berlin"},"type":"Feature"}` + "\n",
+			invalid: []string{
+				`line 3: the coordinates "", "" are not numbers`,
+				"line 4: expected 3 columns, got 2",
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(p, []byte(c.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			invalid, err := convertSourceFile(p, &out)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if out.String() != c.expected {
+				t.Errorf("converted to %q, expected %q", out.String(), c.expected)
+			}
+
+			if len(invalid) != len(c.invalid) {
+				t.Fatalf("got invalid features %v, expected %v", invalid, c.invalid)
+			}
+
+			for i, featureErr := range invalid {
+				if featureErr.Error() != c.invalid[i] {
+					t.Errorf("invalid feature %d is %q, expected %q", i, featureErr, c.invalid[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConvertSourceFile_errors(t *testing.T) {
+	cases := map[string]string{
+		"points.csv":     "name,elevation\nBerlin,34\n",
+		"points.json":    `[{"type": "Feature"}]`,
+		"missing.json":   `{"type": "FeatureCollection"}`,
+		"broken.geojson": `{"type": "FeatureCollection", "features": [{"type": `,
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			if _, err := convertSourceFile(p, &out); err == nil {
+				t.Errorf("expected an error, converted to %q", out.String())
+			}
+		})
+	}
+}
+
+func TestConvertSourceFile_shapefile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "parks.zip")
+	writeTestShapefile(t, p, "")
+
+	var out bytes.Buffer
+	invalid, err := convertSourceFile(p, &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := strings.Join([]string{
+		`{"geometry":{"coordinates":[13.4,52.5],"type":"Point"},"properties":{"NAME":"Tiergarten","OPEN":true,"SIZE":210},"type":"Feature"}`,
+		`{"geometry":{"coordinates":[[[0,0],[1,0],[1,1],[0,0]]],"type":"Polygon"},"properties":{"NAME":"Null Island","OPEN":false,"SIZE":null},"type":"Feature"}`,
+	}, "\n") + "\n"
+
+	if out.String() != expected {
+		t.Errorf("converted to %q, expected %q", out.String(), expected)
+	}
+
+	expectedInvalid := "record 3: the position [500 0] is outside of the longitude and latitude range"
+	if len(invalid) != 1 || invalid[0].Error() != expectedInvalid {
+		t.Errorf("got invalid features %v, expected %q", invalid, expectedInvalid)
+	}
+
+	projected := filepath.Join(t.TempDir(), "projected.zip")
+	writeTestShapefile(t, projected, `PROJCS["WGS_1984_Web_Mercator_Auxiliary_Sphere"]`)
+
+	if _, err := convertSourceFile(projected, &out); err == nil || !strings.Contains(err.Error(), "reproject") {
+		t.Errorf("expected projected shapefiles to fail, got error: %v", err)
+	}
+}
+
+// writeTestShapefile writes a zipped shapefile with a point, a polygon and a point outside of
+// the WGS 84 range.
+func writeTestShapefile(t *testing.T, p, projection string) {
+	t.Helper()
+
+	le := binary.LittleEndian
+
+	var shp bytes.Buffer
+	header := make([]byte, shapeHeaderSize)
+	binary.BigEndian.PutUint32(header, shapeFileCode)
+	shp.Write(header)
+
+	writeRecord := func(number int, content []byte) {
+		recordHeader := make([]byte, 8)
+		binary.BigEndian.PutUint32(recordHeader, uint32(number))
+		binary.BigEndian.PutUint32(recordHeader[4:], uint32(len(content)/2))
+		shp.Write(recordHeader)
+		shp.Write(content)
+	}
+
+	point := func(x, y float64) []byte {
+		content := make([]byte, 20)
+		le.PutUint32(content, shapePoint)
+		le.PutUint64(content[4:], math.Float64bits(x))
+		le.PutUint64(content[12:], math.Float64bits(y))
+		return content
+	}
+
+	// A clockwise outer ring, which becomes counterclockwise in GeoJSON.
+	ring := [][2]float64{{0, 0}, {1, 1}, {1, 0}, {0, 0}}
+	polygon := make([]byte, 48+16*len(ring))
+	le.PutUint32(polygon, shapePolygon)
+	le.PutUint32(polygon[36:], 1)
+	le.PutUint32(polygon[40:], uint32(len(ring)))
+	for i, position := range ring {
+		le.PutUint64(polygon[48+16*i:], math.Float64bits(position[0]))
+		le.PutUint64(polygon[56+16*i:], math.Float64bits(position[1]))
+	}
+
+	writeRecord(1, point(13.4, 52.5))
+	writeRecord(2, polygon)
+	writeRecord(3, point(500, 0))
+
+	fields := []dbfField{{"NAME", 'C', 12}, {"SIZE", 'N', 5}, {"OPEN", 'L', 1}}
+	records := [][]string{{"Tiergarten", "210", "T"}, {"Null Island", "", "F"}, {"Nowhere", "0", "?"}}
+
+	var dbf bytes.Buffer
+	dbfHeader := make([]byte, dbfFieldSize)
+	dbfHeader[0] = 3
+	le.PutUint32(dbfHeader[4:], uint32(len(records)))
+	le.PutUint16(dbfHeader[8:], uint16(dbfFieldSize*(len(fields)+1)+1))
+	dbf.Write(dbfHeader)
+
+	for _, field := range fields {
+		descriptor := make([]byte, dbfFieldSize)
+		copy(descriptor, field.Name)
+		descriptor[11] = field.Type
+		descriptor[16] = byte(field.Length)
+		dbf.Write(descriptor)
+	}
+	dbf.WriteByte(dbfHeaderEnd)
+
+	for _, record := range records {
+		dbf.WriteByte(' ')
+		for i, value := range record {
+			dbf.WriteString(value + strings.Repeat(" ", fields[i].Length-len(value)))
+		}
+	}
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+
+	files := map[string][]byte{"parks/parks.shp": shp.Bytes(), "parks/parks.dbf": dbf.Bytes()}
+	if projection != "" {
+		files["parks/parks.prj"] = []byte(projection)
+	}
+
+	for name, content := range files {
+		file, err := writer.Create(name)
+		if err == nil {
+			_, err = file.Write(content)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(p, archive.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
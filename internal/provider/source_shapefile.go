@@ -0,0 +1,481 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Shape types of the ESRI Shapefile specification. The Z and M variants share the layout of
+// the plain types, followed by the measures that are not converted.
+const (
+	shapeNull        = 0
+	shapePoint       = 1
+	shapePolyLine    = 3
+	shapePolygon     = 5
+	shapeMultiPoint  = 8
+	shapeZOffset     = 10
+	shapeMOffset     = 20
+	shapeFileCode    = 9994
+	shapeHeaderSize  = 100
+	dbfFieldSize     = 32
+	dbfHeaderEnd     = 0x0d
+	dbfDeletedRecord = '*'
+)
+
+// dbfField is a column of the attribute table of a shapefile.
+type dbfField struct {
+	Name   string
+	Type   byte
+	Length int
+}
+
+// convertShapefile converts the shapefile in a zip archive, reading its geometries and
+// attributes record by record. Shapefiles must use WGS 84 coordinates.
+func convertShapefile(p string, w io.Writer) ([]featureError, error) {
+	archive, err := zip.OpenReader(p)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+
+	files := map[string]*zip.File{}
+	var shp string
+
+	for _, file := range archive.File {
+		ext := strings.ToLower(path.Ext(file.Name))
+		if strings.HasPrefix(path.Base(file.Name), ".") || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+
+		if ext == ".shp" {
+			if shp != "" {
+				return nil, fmt.Errorf("the archive contains more than one shapefile: %s, %s", shp, file.Name)
+			}
+			shp = file.Name
+		}
+
+		files[strings.ToLower(file.Name)] = file
+	}
+
+	if shp == "" {
+		return nil, fmt.Errorf("the archive contains no .shp file")
+	}
+
+	base := strings.ToLower(strings.TrimSuffix(shp, path.Ext(shp)))
+
+	dbf, ok := files[base+".dbf"]
+	if !ok {
+		return nil, fmt.Errorf("the archive contains no .dbf file for %s", shp)
+	}
+
+	if prj, ok := files[base+".prj"]; ok {
+		projection, err := readZipFile(prj)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(projection), "PROJCS") {
+			return nil, fmt.Errorf("%s uses a projected coordinate system, reproject it to WGS 84 (EPSG:4326)", shp)
+		}
+	}
+
+	shpFile, err := files[strings.ToLower(shp)].Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = shpFile.Close()
+	}()
+
+	dbfFile, err := dbf.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = dbfFile.Close()
+	}()
+
+	shapes := bufio.NewReader(shpFile)
+	attributes := bufio.NewReader(dbfFile)
+	out := bufio.NewWriter(w)
+
+	header := make([]byte, shapeHeaderSize)
+	if _, err := io.ReadFull(shapes, header); err != nil {
+		return nil, fmt.Errorf("read %s header: %w", shp, err)
+	}
+
+	if binary.BigEndian.Uint32(header) != shapeFileCode {
+		return nil, fmt.Errorf("%s is not a shapefile", shp)
+	}
+
+	fields, records, err := readDbfHeader(attributes)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", dbf.Name, err)
+	}
+
+	var invalid []featureError
+
+	for record := 1; ; record++ {
+		content, err := readShapeRecord(shapes)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return invalid, fmt.Errorf("record %d: %w", record, err)
+		}
+
+		if record > records {
+			return invalid, fmt.Errorf("record %d has no attributes in %s", record, dbf.Name)
+		}
+
+		properties, deleted, err := readDbfRecord(attributes, fields)
+		if err != nil {
+			return invalid, fmt.Errorf("record %d: %w", record, err)
+		}
+
+		if deleted {
+			continue
+		}
+
+		location := fmt.Sprintf("record %d", record)
+
+		geometry, err := decodeShape(content)
+		if err != nil {
+			invalid = append(invalid, featureError{location, err.Error()})
+			continue
+		}
+
+		if err := validateShape(geometry); err != nil {
+			invalid = append(invalid, featureError{location, err.Error()})
+			continue
+		}
+
+		if err := writeFeature(out, geometry, properties); err != nil {
+			return invalid, err
+		}
+	}
+
+	return invalid, out.Flush()
+}
+
+func readZipFile(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	content, err := io.ReadAll(reader)
+	return string(content), err
+}
+
+// readShapeRecord reads the content of the next record of a .shp file.
+func readShapeRecord(r io.Reader) ([]byte, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated record header")
+		}
+		return nil, err
+	}
+
+	// The content length is counted in 16-bit words.
+	content := make([]byte, 2*int(binary.BigEndian.Uint32(header[4:])))
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, fmt.Errorf("truncated record: %w", err)
+	}
+
+	return content, nil
+}
+
+// decodeShape turns the content of a shape record into a GeoJSON geometry.
+func decodeShape(content []byte) (map[string]any, error) {
+	if len(content) < 4 {
+		return nil, fmt.Errorf("the record is too short")
+	}
+
+	shapeType := int(binary.LittleEndian.Uint32(content))
+	if shapeType > shapeMOffset {
+		shapeType -= shapeMOffset
+	} else if shapeType > shapeZOffset {
+		shapeType -= shapeZOffset
+	}
+
+	switch shapeType {
+	case shapeNull:
+		return nil, fmt.Errorf("the record has no geometry")
+	case shapePoint:
+		if len(content) < 20 {
+			return nil, fmt.Errorf("the point record is too short")
+		}
+
+		return map[string]any{"type": "Point", "coordinates": readPoint(content[4:])}, nil
+	case shapeMultiPoint:
+		if len(content) < 40 {
+			return nil, fmt.Errorf("the multipoint record is too short")
+		}
+
+		count := int(binary.LittleEndian.Uint32(content[36:]))
+		if len(content) < 40+16*count {
+			return nil, fmt.Errorf("the multipoint record is too short for %d points", count)
+		}
+
+		points := make([][]float64, count)
+		for i := range points {
+			points[i] = readPoint(content[40+16*i:])
+		}
+
+		return map[string]any{"type": "MultiPoint", "coordinates": points}, nil
+	case shapePolyLine, shapePolygon:
+		parts, err := readParts(content)
+		if err != nil {
+			return nil, err
+		}
+
+		if shapeType == shapePolyLine {
+			if len(parts) == 1 {
+				return map[string]any{"type": "LineString", "coordinates": parts[0]}, nil
+			}
+
+			return map[string]any{"type": "MultiLineString", "coordinates": parts}, nil
+		}
+
+		polygons := groupRings(parts)
+		if len(polygons) == 1 {
+			return map[string]any{"type": "Polygon", "coordinates": polygons[0]}, nil
+		}
+
+		return map[string]any{"type": "MultiPolygon", "coordinates": polygons}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported shape type %d", shapeType)
+}
+
+// readParts reads the parts of a polyline or polygon record, after its type and bounding box.
+func readParts(content []byte) ([][][]float64, error) {
+	if len(content) < 44 {
+		return nil, fmt.Errorf("the record is too short")
+	}
+
+	numParts := int(binary.LittleEndian.Uint32(content[36:]))
+	numPoints := int(binary.LittleEndian.Uint32(content[40:]))
+	pointsStart := 44 + 4*numParts
+
+	if numParts == 0 || len(content) < pointsStart+16*numPoints {
+		return nil, fmt.Errorf("the record is too short for %d parts and %d points", numParts, numPoints)
+	}
+
+	parts := make([][][]float64, numParts)
+	for i := range parts {
+		start := int(binary.LittleEndian.Uint32(content[44+4*i:]))
+		end := numPoints
+		if i+1 < numParts {
+			end = int(binary.LittleEndian.Uint32(content[44+4*(i+1):]))
+		}
+
+		if start > end || end > numPoints {
+			return nil, fmt.Errorf("part %d has invalid point indexes", i+1)
+		}
+
+		for j := start; j < end; j++ {
+			parts[i] = append(parts[i], readPoint(content[pointsStart+16*j:]))
+		}
+	}
+
+	return parts, nil
+}
+
+// groupRings groups the rings of a polygon record into polygons. Shapefiles store outer rings
+// clockwise followed by their holes counterclockwise, GeoJSON uses the opposite orientation.
+func groupRings(rings [][][]float64) [][][][]float64 {
+	var polygons [][][][]float64
+
+	for _, ring := range rings {
+		clockwise := ringArea(ring) < 0
+		reversed := make([][]float64, len(ring))
+		for i, point := range ring {
+			reversed[len(ring)-1-i] = point
+		}
+
+		if clockwise || len(polygons) == 0 {
+			polygons = append(polygons, [][][]float64{reversed})
+			continue
+		}
+
+		last := len(polygons) - 1
+		polygons[last] = append(polygons[last], reversed)
+	}
+
+	return polygons
+}
+
+// ringArea is the signed area of a ring, negative for clockwise rings.
+func ringArea(ring [][]float64) float64 {
+	var area float64
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+
+	return area / 2
+}
+
+func readPoint(content []byte) []float64 {
+	return []float64{
+		math.Float64frombits(binary.LittleEndian.Uint64(content)),
+		math.Float64frombits(binary.LittleEndian.Uint64(content[8:])),
+	}
+}
+
+// validateShape checks a converted geometry with the same rules as GeoJSON features.
+func validateShape(geometry map[string]any) error {
+	switch coordinates := geometry["coordinates"].(type) {
+	case []float64:
+		return validatePosition(coordinates)
+	case [][]float64:
+		if geometry["type"] == "LineString" {
+			return validatePositions(coordinates, 2)
+		}
+		return validatePositions(coordinates, 1)
+	case [][][]float64:
+		if geometry["type"] == "Polygon" {
+			return validatePolygon(coordinates)
+		}
+		for _, line := range coordinates {
+			if err := validatePositions(line, 2); err != nil {
+				return err
+			}
+		}
+	case [][][][]float64:
+		for _, rings := range coordinates {
+			if err := validatePolygon(rings); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readDbfHeader reads the field descriptors of a dBASE file and returns them with the
+// number of records.
+func readDbfHeader(r *bufio.Reader) ([]dbfField, int, error) {
+	header := make([]byte, dbfFieldSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, 0, fmt.Errorf("read header: %w", err)
+	}
+
+	records := int(binary.LittleEndian.Uint32(header[4:]))
+	headerSize := int(binary.LittleEndian.Uint16(header[8:]))
+	read := dbfFieldSize
+
+	var fields []dbfField
+
+	for {
+		marker, err := r.Peek(1)
+		if err != nil {
+			return nil, 0, fmt.Errorf("read fields: %w", err)
+		}
+
+		if marker[0] == dbfHeaderEnd {
+			break
+		}
+
+		descriptor := make([]byte, dbfFieldSize)
+		if _, err := io.ReadFull(r, descriptor); err != nil {
+			return nil, 0, fmt.Errorf("read fields: %w", err)
+		}
+		read += dbfFieldSize
+
+		name, _, _ := strings.Cut(string(descriptor[:11]), "\x00")
+		fields = append(fields, dbfField{Name: name, Type: descriptor[11], Length: int(descriptor[16])})
+	}
+
+	// Skips the terminator and anything else up to the first record.
+	if _, err := r.Discard(headerSize - read); err != nil {
+		return nil, 0, fmt.Errorf("read header: %w", err)
+	}
+
+	return fields, records, nil
+}
+
+// readDbfRecord reads the next record of a dBASE file as feature properties.
+func readDbfRecord(r io.Reader, fields []dbfField) (map[string]any, bool, error) {
+	size := 1
+	for _, field := range fields {
+		size += field.Length
+	}
+
+	record := make([]byte, size)
+	if _, err := io.ReadFull(r, record); err != nil {
+		return nil, false, fmt.Errorf("read attributes: %w", err)
+	}
+
+	if record[0] == dbfDeletedRecord {
+		return nil, true, nil
+	}
+
+	properties := make(map[string]any, len(fields))
+	offset := 1
+
+	for _, field := range fields {
+		value := strings.TrimSpace(dbfString(record[offset : offset+field.Length]))
+		offset += field.Length
+
+		switch field.Type {
+		case 'N', 'F':
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				properties[field.Name] = number
+			} else {
+				properties[field.Name] = nil
+			}
+		case 'L':
+			switch strings.ToUpper(value) {
+			case "T", "Y":
+				properties[field.Name] = true
+			case "F", "N":
+				properties[field.Name] = false
+			default:
+				properties[field.Name] = nil
+			}
+		case 'D':
+			if len(value) == 8 {
+				value = value[:4] + "-" + value[4:6] + "-" + value[6:]
+			}
+			properties[field.Name] = value
+		default:
+			properties[field.Name] = value
+		}
+	}
+
+	return properties, false, nil
+}
+
+// dbfString decodes a dBASE value, which is UTF-8 in current files and Latin-1 in older ones.
+func dbfString(value []byte) string {
+	if utf8.Valid(value) {
+		return string(value)
+	}
+
+	runes := make([]rune, len(value))
+	for i, b := range value {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// styleServerFields are set by Mapbox when a style is saved and are never part of the configuration.
+var styleServerFields = []string{"id", "owner", "created", "modified", "visibility", "draft", "protected"}
+
+// normalizeStyleDocument decodes a style document and drops the server managed fields.
+// Numbers are decoded as float64, so equivalent formats such as 1, 1.0 and 1e0 compare equal.
+func normalizeStyleDocument(raw string) (map[string]any, error) {
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		return nil, err
+	}
+
+	for _, field := range styleServerFields {
+		delete(doc, field)
+	}
+
+	return doc, nil
+}
+
+// styleDocumentsEqual reports whether two style documents only differ in server managed
+// fields and key order.
+func styleDocumentsEqual(a, b string) bool {
+	docA, err := normalizeStyleDocument(a)
+	if err != nil {
+		return false
+	}
+
+	docB, err := normalizeStyleDocument(b)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(docA, docB)
+}
+
+// diffStyleDocuments describes the changes between two normalized style documents, one line
+// per added, removed or changed layer, source or top-level property.
+func diffStyleDocuments(prior, planned map[string]any) []string {
+	var changes []string
+
+	for _, key := range sortedKeys(prior, planned) {
+		switch key {
+		case "layers":
+			changes = append(changes, diffStyleLayers(styleLayers(prior[key]), styleLayers(planned[key]))...)
+		case "sources":
+			priorSources, _ := prior[key].(map[string]any)
+			plannedSources, _ := planned[key].(map[string]any)
+
+			for _, name := range sortedKeys(priorSources, plannedSources) {
+				if change := diffEntry(fmt.Sprintf("source %q", name), priorSources, plannedSources, name); change != "" {
+					changes = append(changes, change)
+				}
+			}
+		default:
+			if change := diffEntry(key, prior, planned, key); change != "" {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	return changes
+}
+
+func diffEntry(label string, prior, planned map[string]any, key string) string {
+	priorValue, inPrior := prior[key]
+	plannedValue, inPlanned := planned[key]
+
+	switch {
+	case !inPrior && inPlanned:
+		return "  + " + label
+	case inPrior && !inPlanned:
+		return "  - " + label
+	case !reflect.DeepEqual(priorValue, plannedValue):
+		return "  ~ " + label
+	}
+
+	return ""
+}
+
+func diffStyleLayers(prior, planned []map[string]any) []string {
+	var changes []string
+
+	priorById := map[string]map[string]any{}
+	var priorOrder []string
+	for _, layer := range prior {
+		id, _ := layer["id"].(string)
+		priorById[id] = layer
+		priorOrder = append(priorOrder, id)
+	}
+
+	plannedIds := map[string]bool{}
+	var plannedOrder []string
+	for _, layer := range planned {
+		id, _ := layer["id"].(string)
+		plannedIds[id] = true
+
+		priorLayer, found := priorById[id]
+		if !found {
+			changes = append(changes, fmt.Sprintf("  + layer %q", id))
+			continue
+		}
+
+		plannedOrder = append(plannedOrder, id)
+
+		if paths := diffProperties("", priorLayer, layer); len(paths) > 0 {
+			changes = append(changes, fmt.Sprintf("  ~ layer %q: %s", id, strings.Join(paths, ", ")))
+		}
+	}
+
+	var keptOrder []string
+	for _, id := range priorOrder {
+		if !plannedIds[id] {
+			changes = append(changes, fmt.Sprintf("  - layer %q", id))
+			continue
+		}
+
+		keptOrder = append(keptOrder, id)
+	}
+
+	if !reflect.DeepEqual(keptOrder, plannedOrder) {
+		changes = append(changes, "  ~ layer order")
+	}
+
+	return changes
+}
+
+// diffProperties returns the dotted paths of the properties that differ, descending into
+// objects such as paint and layout but treating arrays, e.g. expressions, as single values.
+func diffProperties(prefix string, prior, planned map[string]any) []string {
+	var paths []string
+
+	for _, key := range sortedKeys(prior, planned) {
+		priorValue, plannedValue := prior[key], planned[key]
+		if reflect.DeepEqual(priorValue, plannedValue) {
+			continue
+		}
+
+		priorObject, priorIsObject := priorValue.(map[string]any)
+		plannedObject, plannedIsObject := plannedValue.(map[string]any)
+		if priorIsObject && plannedIsObject {
+			paths = append(paths, diffProperties(prefix+key+".", priorObject, plannedObject)...)
+			continue
+		}
+
+		paths = append(paths, prefix+key)
+	}
+
+	return paths
+}
+
+func styleLayers(value any) []map[string]any {
+	items, _ := value.([]any)
+
+	layers := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if layer, ok := item.(map[string]any); ok {
+			layers = append(layers, layer)
+		}
+	}
+
+	return layers
+}
+
+func sortedKeys(maps ...map[string]any) []string {
+	seen := map[string]bool{}
+	var keys []string
+
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// NormalizeStyleJSON returns a style document without the server managed fields as
+// indented JSON with sorted keys, the form used when writing styles into configuration.
+func NormalizeStyleJSON(raw string) (string, error) {
+	doc, err := normalizeStyleDocument(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return marshalStyleJSON(doc)
+}
+
+// marshalStyleJSON encodes a style document as indented JSON without a trailing newline.
+// Unlike json.MarshalIndent it keeps <, > and & as they are, so expressions such as
+// [">=", ...] stay readable.
+func marshalStyleJSON(doc any) (string, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStyleDocumentsEqual(t *testing.T) {
+	configured := `{"version": 8, "name": "test", "sources": {}, "layers": [{"id": "water", "type": "fill", "paint": {"fill-opacity": 0.5}}]}`
+
+	cases := map[string]struct {
+		other string
+		equal bool
+	}{
+		"server fields and key order": {
+			other: `{"layers": [{"paint": {"fill-opacity": 0.5}, "type": "fill", "id": "water"}], "id": "abc", "owner": "user", "created": "2024-01-01T00:00:00.000Z", "modified": "2024-01-02T00:00:00.000Z", "sources": {}, "name": "test", "version": 8}`,
+			equal: true,
+		},
+		"number formats": {
+			other: `{"version": 8.0, "name": "test", "sources": {}, "layers": [{"id": "water", "type": "fill", "paint": {"fill-opacity": 5e-1}}]}`,
+			equal: true,
+		},
+		"changed paint": {
+			other: `{"version": 8, "name": "test", "sources": {}, "layers": [{"id": "water", "type": "fill", "paint": {"fill-opacity": 1}}]}`,
+			equal: false,
+		},
+		"invalid json": {
+			other: `{"version": 8`,
+			equal: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := styleDocumentsEqual(configured, tc.other); got != tc.equal {
+				t.Errorf("expected %t, got %t", tc.equal, got)
+			}
+		})
+	}
+}
+
+func TestDiffStyleDocuments(t *testing.T) {
+	prior, err := normalizeStyleDocument(`{
+  "version": 8,
+  "name": "before",
+  "sources": {"composite": {"type": "vector", "url": "mapbox://mapbox.mapbox-streets-v8"}},
+  "layers": [
+    {"id": "background", "type": "background"},
+    {"id": "water", "type": "fill", "paint": {"fill-color": "#0000ff", "fill-opacity": 1}},
+    {"id": "roads", "type": "line"},
+    {"id": "poi", "type": "symbol"}
+  ]
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	planned, err := normalizeStyleDocument(`{
+  "version": 8,
+  "name": "after",
+  "sources": {
+    "composite": {"type": "vector", "url": "mapbox://mapbox.mapbox-streets-v8"},
+    "extra": {"type": "geojson", "data": {"type": "FeatureCollection", "features": []}}
+  },
+  "layers": [
+    {"id": "background", "type": "background"},
+    {"id": "roads", "type": "line"},
+    {"id": "water", "type": "fill", "paint": {"fill-color": "#0000aa", "fill-opacity": 1}, "layout": {"visibility": "none"}},
+    {"id": "labels", "type": "symbol"}
+  ]
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`  ~ layer "water": layout, paint.fill-color`,
+		`  + layer "labels"`,
+		`  - layer "poi"`,
+		`  ~ layer order`,
+		`  ~ name`,
+		`  + source "extra"`,
+	}
+
+	if got := diffStyleDocuments(prior, planned); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected diff:\n%#v", got)
+	}
+
+	if got := diffStyleDocuments(prior, prior); len(got) != 0 {
+		t.Errorf("expected no changes, got: %#v", got)
+	}
+}
+
+func TestNormalizeStyleJSON(t *testing.T) {
+	normalized, err := NormalizeStyleJSON(`{"version": 8, "id": "cjz5g2fue0ed61cp6wy7tn1xe", "layers": [{"id": "tall & wide", "type": "fill", "filter": [">=", ["get", "height"], 100]}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{
+  "layers": [
+    {
+      "filter": [
+        ">=",
+        [
+          "get",
+          "height"
+        ],
+        100
+      ],
+      "id": "tall & wide",
+      "type": "fill"
+    }
+  ],
+  "version": 8
+}`
+
+	if normalized != expected {
+		t.Errorf("NormalizeStyleJSON() = %s, expected %s", normalized, expected)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = StyleJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = StyleJSON{}
+	_ xattr.ValidateableAttribute                = StyleJSON{}
+)
+
+// StyleJSONType is the attribute type of style documents. Its values are semantically
+// equal when they only differ in key order, number formatting or server managed fields.
+type StyleJSONType struct {
+	basetypes.StringType
+}
+
+func (t StyleJSONType) String() string {
+	return "provider.StyleJSONType"
+}
+
+func (t StyleJSONType) ValueType(ctx context.Context) attr.Value {
+	return StyleJSON{}
+}
+
+func (t StyleJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(StyleJSONType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t StyleJSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return StyleJSON{StringValue: in}, nil
+}
+
+func (t StyleJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return StyleJSON{StringValue: stringValue}, nil
+}
+
+// StyleJSON is a style document value.
+type StyleJSON struct {
+	basetypes.StringValue
+}
+
+func NewStyleJSONValue(value string) StyleJSON {
+	return StyleJSON{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v StyleJSON) Type(ctx context.Context) attr.Type {
+	return StyleJSONType{}
+}
+
+func (v StyleJSON) Equal(o attr.Value) bool {
+	other, ok := o.(StyleJSON)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals keeps the prior document in state when Mapbox returns an equivalent one.
+func (v StyleJSON) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(StyleJSON)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	return styleDocumentsEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+func (v StyleJSON) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(v.ValueString()), &doc); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Style Document", fmt.Sprintf("The style must be a JSON object, got error: %s", err))
+	}
+}
+
+// styleJSONPlanModifier keeps the prior document when the configured one is semantically
+// equal to it, and otherwise reports which layers and sources the plan changes.
+type styleJSONPlanModifier struct{}
+
+func (m styleJSONPlanModifier) Description(ctx context.Context) string {
+	return "Ignores formatting only changes of the style document and summarizes the layer changes."
+}
+
+func (m styleJSONPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m styleJSONPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	prior, err := normalizeStyleDocument(req.StateValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	planned, err := normalizeStyleDocument(req.PlanValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	changes := diffStyleDocuments(prior, planned)
+	if len(changes) == 0 {
+		resp.PlanValue = req.StateValue
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		req.Path,
+		"Style Changes",
+		"The style document will be updated:\n\n"+strings.Join(changes, "\n"),
+	)
+}
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+// The subset of the Mapbox GL style specification (v8) used to validate style documents
+// offline, see https://docs.mapbox.com/style-spec/reference/.
+
+const styleSpecVersion = 8
+
+// styleSourceTypes maps the source types to the properties one of which must be set.
+var styleSourceTypes = map[string][]string{
+	"vector":        {"url", "tiles"},
+	"raster":        {"url", "tiles"},
+	"raster-dem":    {"url", "tiles"},
+	"raster-array":  {"url", "tiles"},
+	"geojson":       {"data"},
+	"image":         {"url"},
+	"video":         {"urls"},
+	"model":         {"models"},
+	"batched-model": {"url", "tiles"},
+}
+
+type styleLayerSpec struct {
+	// sourceless layers must not reference a source, every other layer must.
+	sourceless bool
+	paint      []string
+	layout     []string
+}
+
+var styleLayerTypes = map[string]styleLayerSpec{
+	"background": {
+		sourceless: true,
+		paint:      []string{"background-color", "background-pattern", "background-opacity", "background-emissive-strength"},
+	},
+	"fill": {
+		paint: []string{
+			"fill-antialias", "fill-opacity", "fill-color", "fill-outline-color", "fill-translate", "fill-translate-anchor",
+			"fill-pattern", "fill-emissive-strength", "fill-z-offset", "fill-bridge-guard-rail-color", "fill-tunnel-structure-color",
+		},
+		layout: []string{"fill-sort-key", "fill-elevation-reference", "fill-construct-bridge-guard-rail"},
+	},
+	"line": {
+		paint: []string{
+			"line-opacity", "line-color", "line-translate", "line-translate-anchor", "line-width", "line-gap-width", "line-offset",
+			"line-blur", "line-dasharray", "line-pattern", "line-gradient", "line-trim-offset", "line-trim-fade-range",
+			"line-trim-color", "line-emissive-strength", "line-border-width", "line-border-color", "line-occlusion-opacity",
+		},
+		layout: []string{
+			"line-cap", "line-join", "line-miter-limit", "line-round-limit", "line-sort-key", "line-z-offset",
+			"line-elevation-reference", "line-cross-slope", "line-width-unit",
+		},
+	},
+	"symbol": {
+		paint: []string{
+			"icon-opacity", "icon-occlusion-opacity", "icon-emissive-strength", "icon-color", "icon-halo-color", "icon-halo-width",
+			"icon-halo-blur", "icon-translate", "icon-translate-anchor", "icon-image-cross-fade", "icon-color-saturation",
+			"icon-color-contrast", "icon-color-brightness-min", "icon-color-brightness-max", "text-opacity",
+			"text-occlusion-opacity", "text-color", "text-emissive-strength", "text-halo-color", "text-halo-width",
+			"text-halo-blur", "text-translate", "text-translate-anchor", "symbol-z-offset",
+		},
+		layout: []string{
+			"symbol-placement", "symbol-spacing", "symbol-avoid-edges", "symbol-sort-key", "symbol-z-order", "symbol-z-elevate",
+			"symbol-elevation-reference", "icon-allow-overlap", "icon-ignore-placement", "icon-optional",
+			"icon-rotation-alignment", "icon-size", "icon-size-scale-range", "icon-text-fit", "icon-text-fit-padding",
+			"icon-image", "icon-rotate", "icon-padding", "icon-keep-upright", "icon-offset", "icon-anchor",
+			"icon-pitch-alignment", "text-pitch-alignment", "text-rotation-alignment", "text-field", "text-font", "text-size",
+			"text-size-scale-range", "text-max-width", "text-line-height", "text-letter-spacing", "text-justify",
+			"text-radial-offset", "text-variable-anchor", "text-anchor", "text-max-angle", "text-writing-mode", "text-rotate",
+			"text-padding", "text-keep-upright", "text-transform", "text-offset", "text-allow-overlap",
+			"text-ignore-placement", "text-optional",
+		},
+	},
+	"circle": {
+		paint: []string{
+			"circle-radius", "circle-color", "circle-blur", "circle-opacity", "circle-translate", "circle-translate-anchor",
+			"circle-pitch-scale", "circle-pitch-alignment", "circle-stroke-width", "circle-stroke-color",
+			"circle-stroke-opacity", "circle-emissive-strength",
+		},
+		layout: []string{"circle-sort-key", "circle-elevation-reference"},
+	},
+	"heatmap": {
+		paint: []string{"heatmap-radius", "heatmap-weight", "heatmap-intensity", "heatmap-color", "heatmap-opacity"},
+	},
+	"fill-extrusion": {
+		paint: []string{
+			"fill-extrusion-opacity", "fill-extrusion-color", "fill-extrusion-translate", "fill-extrusion-translate-anchor",
+			"fill-extrusion-pattern", "fill-extrusion-height", "fill-extrusion-base", "fill-extrusion-vertical-gradient",
+			"fill-extrusion-ambient-occlusion-intensity", "fill-extrusion-ambient-occlusion-radius",
+			"fill-extrusion-ambient-occlusion-wall-radius", "fill-extrusion-ambient-occlusion-ground-radius",
+			"fill-extrusion-ambient-occlusion-ground-attenuation", "fill-extrusion-flood-light-color",
+			"fill-extrusion-flood-light-intensity", "fill-extrusion-flood-light-wall-radius",
+			"fill-extrusion-flood-light-ground-radius", "fill-extrusion-flood-light-ground-attenuation",
+			"fill-extrusion-vertical-scale", "fill-extrusion-rounded-roof", "fill-extrusion-cutoff-fade-range",
+			"fill-extrusion-emissive-strength", "fill-extrusion-line-width", "fill-extrusion-cast-shadows",
+		},
+		layout: []string{"fill-extrusion-edge-radius"},
+	},
+	"raster": {
+		paint: []string{
+			"raster-opacity", "raster-color", "raster-color-mix", "raster-color-range", "raster-hue-rotate",
+			"raster-brightness-min", "raster-brightness-max", "raster-saturation", "raster-contrast", "raster-resampling",
+			"raster-fade-duration", "raster-emissive-strength", "raster-array-band", "raster-elevation",
+		},
+	},
+	"raster-particle": {
+		paint: []string{
+			"raster-particle-array-band", "raster-particle-count", "raster-particle-color", "raster-particle-max-speed",
+			"raster-particle-speed-factor", "raster-particle-fade-opacity-factor", "raster-particle-reset-rate-factor",
+			"raster-particle-elevation",
+		},
+	},
+	"hillshade": {
+		paint: []string{
+			"hillshade-illumination-direction", "hillshade-illumination-anchor", "hillshade-exaggeration",
+			"hillshade-shadow-color", "hillshade-highlight-color", "hillshade-accent-color", "hillshade-emissive-strength",
+		},
+	},
+	"sky": {
+		sourceless: true,
+		paint: []string{
+			"sky-type", "sky-atmosphere-sun", "sky-atmosphere-sun-intensity", "sky-gradient-center", "sky-gradient-radius",
+			"sky-gradient", "sky-atmosphere-halo-color", "sky-atmosphere-color", "sky-opacity",
+		},
+	},
+	"model": {
+		paint: []string{
+			"model-opacity", "model-rotation", "model-scale", "model-translation", "model-color", "model-color-mix-intensity",
+			"model-type", "model-cast-shadows", "model-receive-shadows", "model-ambient-occlusion-intensity",
+			"model-emissive-strength", "model-roughness", "model-height-based-emissive-strength-multiplier",
+			"model-cutoff-fade-range", "model-front-cutoff",
+		},
+		layout: []string{"model-id"},
+	},
+	"slot": {
+		sourceless: true,
+	},
+	"clip": {
+		layout: []string{"clip-layer-types", "clip-layer-scope"},
+	},
+}
+
+// styleLayoutCommon are layout properties shared by every layer type.
+var styleLayoutCommon = []string{"visibility"}
+
+// styleStringArrayProperties take literal arrays of strings, which must not be mistaken for expressions.
+var styleStringArrayProperties = map[string]bool{
+	"text-font":            true,
+	"text-variable-anchor": true,
+	"text-writing-mode":    true,
+	"clip-layer-types":     true,
+	"clip-layer-scope":     true,
+}
+
+// styleExpressionOperators are the operators of the expression language.
+var styleExpressionOperators = toSet(
+	// types
+	"array", "boolean", "collator", "format", "image", "literal", "number", "number-format", "object", "string",
+	"to-boolean", "to-color", "to-number", "to-string", "typeof",
+	// feature data
+	"accumulated", "feature-state", "geometry-type", "id", "line-progress", "properties",
+	// lookup
+	"at", "at-interpolated", "config", "get", "has", "in", "index-of", "length", "measure-light", "slice", "worldview",
+	// decision
+	"!", "!=", "<", "<=", "==", ">", ">=", "all", "any", "case", "coalesce", "match", "within",
+	// ramps, scales, curves
+	"interpolate", "interpolate-hcl", "interpolate-lab", "step",
+	// variable binding
+	"let", "var",
+	// string
+	"concat", "downcase", "is-supported-script", "resolved-locale", "upcase",
+	// color
+	"hsl", "hsla", "rgb", "rgba", "to-hsla", "to-rgba",
+	// math
+	"-", "*", "/", "%", "^", "+", "abs", "acos", "asin", "atan", "ceil", "cos", "distance", "e", "floor", "ln", "ln2",
+	"log10", "log2", "max", "min", "pi", "random", "round", "sin", "sqrt", "tan",
+	// camera
+	"distance-from-center", "pitch", "zoom",
+	// heatmap, raster and sky
+	"heatmap-density", "raster-value", "raster-particle-speed", "sky-radial-progress",
+)
+
+// styleFilterOperators are the operators that may start a filter, i.e. that produce a boolean.
+// "none", "!has" and "!in" are only valid in the deprecated filter syntax.
+var styleFilterOperators = toSet(
+	"!", "!=", "<", "<=", "==", ">", ">=", "all", "any", "none", "case", "coalesce", "match", "within", "has", "!has",
+	"in", "!in", "boolean", "to-boolean", "literal", "is-supported-script", "feature-state", "get", "config",
+)
+
+var styleInterpolationTypes = toSet("linear", "exponential", "cubic-bezier")
+
+func toSet(items ...string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+
+	return set
+}
@@ -0,0 +1,348 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// styleSpecError is a problem found in a style document, tied to the layer or source it was found in.
+type styleSpecError struct {
+	Layer   string
+	Source  string
+	Message string
+}
+
+func (e styleSpecError) Error() string {
+	switch {
+	case e.Layer != "":
+		return fmt.Sprintf("layer %q: %s", e.Layer, e.Message)
+	case e.Source != "":
+		return fmt.Sprintf("source %q: %s", e.Source, e.Message)
+	}
+
+	return e.Message
+}
+
+// validateStyleDocument checks a style document against the embedded subset of the style
+// specification, without calling the API.
+func validateStyleDocument(doc map[string]any) []styleSpecError {
+	var errs []styleSpecError
+
+	if version, ok := doc["version"].(float64); !ok || version != styleSpecVersion {
+		errs = append(errs, styleSpecError{Message: fmt.Sprintf("version must be %d", styleSpecVersion)})
+	}
+
+	sourceTypes := map[string]string{}
+
+	sources, ok := doc["sources"].(map[string]any)
+	if !ok {
+		errs = append(errs, styleSpecError{Message: "sources must be an object"})
+	}
+
+	for _, name := range sortedKeys(sources) {
+		sourceType, err := validateStyleSource(sources[name])
+		if err != nil {
+			errs = append(errs, styleSpecError{Source: name, Message: err.Error()})
+		}
+
+		sourceTypes[name] = sourceType
+	}
+
+	layers, ok := doc["layers"].([]any)
+	if !ok {
+		errs = append(errs, styleSpecError{Message: "layers must be an array"})
+	}
+
+	ids := map[string]bool{}
+	for i, item := range layers {
+		label := fmt.Sprintf("layers[%d]", i)
+
+		layer, ok := item.(map[string]any)
+		if !ok {
+			errs = append(errs, styleSpecError{Layer: label, Message: "layer must be an object"})
+			continue
+		}
+
+		if id, ok := layer["id"].(string); ok && id != "" {
+			label = id
+
+			if ids[id] {
+				errs = append(errs, styleSpecError{Layer: label, Message: "duplicate layer id"})
+			}
+			ids[id] = true
+		} else {
+			errs = append(errs, styleSpecError{Layer: label, Message: "id must be a non-empty string"})
+		}
+
+		for _, message := range validateStyleLayer(layer, sourceTypes) {
+			errs = append(errs, styleSpecError{Layer: label, Message: message})
+		}
+	}
+
+	return errs
+}
+
+func validateStyleSource(value any) (string, error) {
+	source, ok := value.(map[string]any)
+	if !ok {
+		return "", errors.New("source must be an object")
+	}
+
+	sourceType, _ := source["type"].(string)
+
+	required, known := styleSourceTypes[sourceType]
+	if !known {
+		return "", fmt.Errorf("unknown source type %q", sourceType)
+	}
+
+	for _, property := range required {
+		if _, ok := source[property]; ok {
+			return sourceType, nil
+		}
+	}
+
+	return sourceType, fmt.Errorf("%s sources require one of: %s", sourceType, strings.Join(required, ", "))
+}
+
+// validateStyleLayer checks a single layer. Source references are only checked when the
+// sources of the style are known, i.e. sourceTypes is not nil.
+func validateStyleLayer(layer map[string]any, sourceTypes map[string]string) []string {
+	var messages []string
+
+	layerType, _ := layer["type"].(string)
+
+	spec, known := styleLayerTypes[layerType]
+	if !known {
+		return append(messages, fmt.Sprintf("unknown layer type %q", layerType))
+	}
+
+	source, hasSource := layer["source"].(string)
+	switch {
+	case spec.sourceless && hasSource:
+		messages = append(messages, fmt.Sprintf("%s layers cannot have a source", layerType))
+	case !spec.sourceless && !hasSource:
+		messages = append(messages, fmt.Sprintf("%s layers require a source", layerType))
+	case hasSource && sourceTypes != nil:
+		sourceType, found := sourceTypes[source]
+		if !found {
+			messages = append(messages, fmt.Sprintf("source %q does not exist", source))
+		} else if _, hasSourceLayer := layer["source-layer"]; sourceType == "vector" && !hasSourceLayer {
+			messages = append(messages, fmt.Sprintf("source-layer is required for the vector source %q", source))
+		}
+	}
+
+	minzoom, maxzoom := layer["minzoom"], layer["maxzoom"]
+	for property, value := range map[string]any{"minzoom": minzoom, "maxzoom": maxzoom} {
+		if value == nil {
+			continue
+		}
+
+		if zoom, ok := value.(float64); !ok || zoom < 0 || zoom > 24 {
+			messages = append(messages, fmt.Sprintf("%s must be a number between 0 and 24", property))
+		}
+	}
+
+	if minzoomValue, ok := minzoom.(float64); ok {
+		if maxzoomValue, ok := maxzoom.(float64); ok && minzoomValue > maxzoomValue {
+			messages = append(messages, "minzoom must not be greater than maxzoom")
+		}
+	}
+
+	messages = append(messages, validateStyleProperties(layer, "paint", toSet(spec.paint...), true)...)
+	messages = append(messages, validateStyleProperties(layer, "layout", toSet(append(spec.layout, styleLayoutCommon...)...), false)...)
+
+	if filter, ok := layer["filter"]; ok {
+		if err := validateStyleFilter(filter); err != nil {
+			messages = append(messages, fmt.Sprintf("invalid filter: %s", err))
+		}
+	}
+
+	sort.Strings(messages)
+	return messages
+}
+
+func validateStyleProperties(layer map[string]any, group string, known map[string]bool, transitions bool) []string {
+	value, ok := layer[group]
+	if !ok {
+		return nil
+	}
+
+	properties, ok := value.(map[string]any)
+	if !ok {
+		return []string{fmt.Sprintf("%s must be an object", group)}
+	}
+
+	var messages []string
+	for _, name := range sortedKeys(properties) {
+		property := name
+		if transitions {
+			property = strings.TrimSuffix(name, "-transition")
+		}
+
+		if !known[property] {
+			messages = append(messages, fmt.Sprintf("unknown %s property %q", group, name))
+			continue
+		}
+
+		if err := validateStylePropertyValue(property, properties[name]); err != nil {
+			messages = append(messages, fmt.Sprintf("invalid %s property %q: %s", group, name, err))
+		}
+	}
+
+	return messages
+}
+
+func validateStylePropertyValue(property string, value any) error {
+	items, ok := value.([]any)
+	if !ok || len(items) == 0 {
+		return nil
+	}
+
+	operator, ok := items[0].(string)
+	if !ok {
+		// A literal array such as line-dasharray or text-offset.
+		return nil
+	}
+
+	if styleStringArrayProperties[property] && !styleExpressionOperators[operator] {
+		return nil
+	}
+
+	return validateStyleExpression(items)
+}
+
+// validateStyleExpression checks the operators of an expression and the shape of the
+// operators whose arguments are not all expressions themselves.
+func validateStyleExpression(expr []any) error {
+	if len(expr) == 0 {
+		return errors.New("empty expression")
+	}
+
+	operator, ok := expr[0].(string)
+	if !ok {
+		return fmt.Errorf("expression operator must be a string, got %v", expr[0])
+	}
+
+	if !styleExpressionOperators[operator] {
+		return fmt.Errorf("unknown expression operator %q", operator)
+	}
+
+	args := expr[1:]
+
+	switch operator {
+	case "literal":
+		if len(args) != 1 {
+			return errors.New(`"literal" expects exactly one argument`)
+		}
+
+		return nil
+	case "match":
+		// ["match", input, label, output, ..., fallback], labels are literals.
+		if len(args) < 4 || len(args)%2 != 0 {
+			return errors.New(`"match" expects an input, label and output pairs and a fallback`)
+		}
+
+		expressions := []any{args[0], args[len(args)-1]}
+		for i := 2; i < len(args)-1; i += 2 {
+			expressions = append(expressions, args[i])
+		}
+
+		return validateStyleArguments(expressions)
+	case "interpolate", "interpolate-hcl", "interpolate-lab":
+		// [operator, interpolation, input, stop, output, ...]
+		if len(args) < 4 || len(args)%2 != 0 {
+			return fmt.Errorf("%q expects an interpolation type, an input and stop and output pairs", operator)
+		}
+
+		interpolation, _ := args[0].([]any)
+		if len(interpolation) == 0 {
+			return fmt.Errorf("%q expects an interpolation type such as [\"linear\"]", operator)
+		}
+
+		if name, _ := interpolation[0].(string); !styleInterpolationTypes[name] {
+			return fmt.Errorf("unknown interpolation type %v", interpolation[0])
+		}
+
+		return validateStyleArguments(args[1:])
+	case "step":
+		// ["step", input, output, stop, output, ...]
+		if len(args) < 2 || len(args)%2 != 0 {
+			return errors.New(`"step" expects an input, a default output and stop and output pairs`)
+		}
+	case "case":
+		// ["case", condition, output, ..., fallback]
+		if len(args) < 3 || len(args)%2 != 1 {
+			return errors.New(`"case" expects condition and output pairs and a fallback`)
+		}
+	}
+
+	return validateStyleArguments(args)
+}
+
+func validateStyleArguments(args []any) error {
+	for _, arg := range args {
+		items, ok := arg.([]any)
+		if !ok || len(items) == 0 {
+			continue
+		}
+
+		if err := validateStyleExpression(items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateStyleFilter accepts both expressions and the deprecated filter syntax.
+func validateStyleFilter(value any) error {
+	if _, ok := value.(bool); ok {
+		return nil
+	}
+
+	filter, ok := value.([]any)
+	if !ok || len(filter) == 0 {
+		return errors.New("filter must be a boolean or a non-empty array")
+	}
+
+	operator, ok := filter[0].(string)
+	if !ok {
+		return fmt.Errorf("filter operator must be a string, got %v", filter[0])
+	}
+
+	if !styleFilterOperators[operator] {
+		if styleExpressionOperators[operator] {
+			return fmt.Errorf("%q does not produce a boolean", operator)
+		}
+
+		return fmt.Errorf("unknown filter operator %q", operator)
+	}
+
+	switch operator {
+	case "all", "any", "none":
+		for _, sub := range filter[1:] {
+			if err := validateStyleFilter(sub); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case "!has", "!in":
+		if len(filter) < 2 {
+			return fmt.Errorf("%q expects a property key", operator)
+		}
+
+		if _, ok := filter[1].(string); !ok {
+			return fmt.Errorf("%q expects a property key", operator)
+		}
+
+		return nil
+	}
+
+	return validateStyleExpression(filter)
+}
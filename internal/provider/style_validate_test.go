@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateStyleDocument(t *testing.T) {
+	cases := map[string]struct {
+		document string
+		errors   []string
+	}{
+		"valid": {
+			document: `{
+  "version": 8,
+  "sources": {
+    "composite": {"type": "vector", "url": "mapbox://mapbox.mapbox-streets-v8"},
+    "points": {"type": "geojson", "data": {"type": "FeatureCollection", "features": []}}
+  },
+  "layers": [
+    {"id": "background", "type": "background", "paint": {"background-color": "#fff", "background-opacity-transition": {"duration": 300}}},
+    {
+      "id": "roads",
+      "type": "line",
+      "source": "composite",
+      "source-layer": "road",
+      "minzoom": 5,
+      "filter": ["all", ["==", "class", "street"], ["!has", "tunnel"]],
+      "paint": {
+        "line-width": ["interpolate", ["exponential", 1.5], ["zoom"], 5, 0.5, 18, 8],
+        "line-dasharray": [2, 1],
+        "line-color": ["match", ["get", "class"], ["motorway", "trunk"], "#f00", "#000"]
+      }
+    },
+    {
+      "id": "labels",
+      "type": "symbol",
+      "source": "points",
+      "filter": ["==", ["geometry-type"], "Point"],
+      "layout": {"text-field": ["get", "name"], "text-font": ["Open Sans Regular", "Arial Unicode MS Regular"], "visibility": "visible"}
+    }
+  ]
+}`,
+		},
+		"invalid": {
+			document: `{
+  "version": 7,
+  "sources": {"composite": {"type": "vector"}, "bad": {"type": "tiles"}},
+  "layers": [
+    {"id": "background", "type": "background", "source": "composite"},
+    {"id": "water", "type": "fill", "source": "composite", "paint": {"fill-colour": "#00f"}},
+    {"id": "water", "type": "polygon"},
+    {"id": "roads", "type": "line", "source": "missing", "minzoom": 12, "maxzoom": 10, "filter": ["zoom"]},
+    {"id": "labels", "type": "symbol", "source": "composite", "source-layer": "poi", "layout": {"text-field": ["concat", ["get", "name"], ["uppercase", "x"]]}},
+    {"type": "circle", "source": "composite", "source-layer": "poi", "paint": {"circle-radius": ["step", ["zoom"], 1, 10]}}
+  ]
+}`,
+			errors: []string{
+				`version must be 8`,
+				`source "bad": unknown source type "tiles"`,
+				`source "composite": vector sources require one of: url, tiles`,
+				`layer "background": background layers cannot have a source`,
+				`layer "water": source-layer is required for the vector source "composite"`,
+				`layer "water": unknown paint property "fill-colour"`,
+				`layer "water": duplicate layer id`,
+				`layer "water": unknown layer type "polygon"`,
+				`layer "roads": invalid filter: "zoom" does not produce a boolean`,
+				`layer "roads": minzoom must not be greater than maxzoom`,
+				`layer "roads": source "missing" does not exist`,
+				`layer "labels": invalid layout property "text-field": unknown expression operator "uppercase"`,
+				`layer "layers[5]": id must be a non-empty string`,
+				`layer "layers[5]": invalid paint property "circle-radius": "step" expects an input, a default output and stop and output pairs`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			doc, err := normalizeStyleDocument(tc.document)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, err := range validateStyleDocument(doc) {
+				got = append(got, err.Error())
+			}
+
+			if !reflect.DeepEqual(got, tc.errors) {
+				t.Errorf("unexpected errors:\n%#v", got)
+			}
+		})
+	}
+}
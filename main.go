@@ -5,9 +5,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"log"
+	"os"
 
+	"github.com/drfaust92/terraform-provider-mapbox/internal/command"
 	"github.com/drfaust92/terraform-provider-mapbox/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
@@ -32,6 +35,18 @@ var (
 )
 
 func main() {
+	// Helper subcommands, e.g. `terraform-provider-mapbox generate-imports`. Terraform
+	// itself starts the binary without arguments, which serves the provider.
+	if len(os.Args) > 1 {
+		if cmd, ok := command.Lookup(os.Args[1]); ok {
+			if err := cmd(context.Background(), os.Args[2:], os.Stdout); err != nil && !errors.Is(err, flag.ErrHelp) {
+				log.Fatal(err.Error())
+			}
+
+			return
+		}
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")